@@ -0,0 +1,154 @@
+package ptime
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A Builder accumulates the fields of a time to construct a Time by its Build method.
+//
+// The month and day default to Farvardin 1 and the clock defaults to 00:00:00.
+type Builder struct {
+	year, day, hour, min, sec, nsec int
+	month                           Month
+	hasYear                         bool
+	loc                             *time.Location
+}
+
+// NewBuilder returns a new Builder of a time in the location loc.
+func NewBuilder(loc *time.Location) *Builder {
+	return &Builder{month: Farvardin, day: 1, loc: loc}
+}
+
+// Year sets the year of b.
+func (b *Builder) Year(year int) *Builder {
+	b.year = year
+	b.hasYear = true
+	return b
+}
+
+// Month sets the month of b.
+func (b *Builder) Month(month Month) *Builder {
+	b.month = month
+	return b
+}
+
+// Day sets the day of b.
+func (b *Builder) Day(day int) *Builder {
+	b.day = day
+	return b
+}
+
+// Hour sets the hour of b.
+func (b *Builder) Hour(hour int) *Builder {
+	b.hour = hour
+	return b
+}
+
+// Minute sets the minute of b.
+func (b *Builder) Minute(min int) *Builder {
+	b.min = min
+	return b
+}
+
+// Second sets the second of b.
+func (b *Builder) Second(sec int) *Builder {
+	b.sec = sec
+	return b
+}
+
+// Nanosecond sets the nanosecond of b.
+func (b *Builder) Nanosecond(nsec int) *Builder {
+	b.nsec = nsec
+	return b
+}
+
+// Location sets the location of b.
+func (b *Builder) Location(loc *time.Location) *Builder {
+	b.loc = loc
+	return b
+}
+
+// Build returns a new instance of Time from the fields of b.
+//
+// Unlike Date, the fields are not normalized and an error is returned if
+// the year or location is not set or any field is out of its range.
+func (b *Builder) Build() (Time, error) {
+	switch {
+	case !b.hasYear:
+		return Time{}, errors.New("ptime: the year is not set")
+	case b.loc == nil:
+		return Time{}, errors.New("ptime: the location is not set")
+	case b.month < Farvardin || b.month > Esfand:
+		return Time{}, fmt.Errorf("ptime: month %d out of range", b.month)
+	case b.day < 1 || b.day > monthLength(b.year, b.month):
+		return Time{}, fmt.Errorf("ptime: day %d out of range for %d/%02d", b.day, b.year, b.month)
+	case b.hour < 0 || b.hour > 23:
+		return Time{}, fmt.Errorf("ptime: hour %d out of range", b.hour)
+	case b.min < 0 || b.min > 59:
+		return Time{}, fmt.Errorf("ptime: minute %d out of range", b.min)
+	case b.sec < 0 || b.sec > 59:
+		return Time{}, fmt.Errorf("ptime: second %d out of range", b.sec)
+	case b.nsec < 0 || b.nsec > 999999999:
+		return Time{}, fmt.Errorf("ptime: nanosecond %d out of range", b.nsec)
+	}
+
+	return Date(b.year, b.month, b.day, b.hour, b.min, b.sec, b.nsec, b.loc), nil
+}
+
+// A ValidationError describes the problems of an invalid Time.
+type ValidationError struct {
+	// Problems lists each violated constraint (e.g. "hour 25 out of range").
+	Problems []string
+}
+
+// Error returns the string representation of e.
+func (e *ValidationError) Error() string {
+	return "ptime: invalid time: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks every field of t against the calendar and clock constraints
+// and returns a *ValidationError listing all of the problems, or nil if t is valid.
+//
+// The constructors of Time normalize their arguments, so Validate is useful to
+// audit a Time which is not obtained from them (e.g. the zero value).
+func (t Time) Validate() error {
+	var problems []string
+	add := func(format string, a ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, a...))
+	}
+
+	validMonth := t.month >= Farvardin && t.month <= Esfand
+	if !validMonth {
+		add("month %d out of range", t.month)
+	} else if t.day < 1 || t.day > monthLength(t.year, t.month) {
+		add("day %d invalid for month %d of year %d", t.day, t.month, t.year)
+	} else if wd := jdnWeekday(t.jdn()); t.wday != wd {
+		add("weekday %d does not match the date (%d)", t.wday, wd)
+	}
+	if !validMonth && (t.day < 1 || t.day > 31) {
+		add("day %d out of range", t.day)
+	}
+	if t.hour < 0 || t.hour > 23 {
+		add("hour %d out of range", t.hour)
+	}
+	if t.min < 0 || t.min > 59 {
+		add("minute %d out of range", t.min)
+	}
+	if t.sec < 0 || t.sec > 59 {
+		add("second %d out of range", t.sec)
+	}
+	if t.nsec < 0 || t.nsec > 999999999 {
+		add("nanosecond %d out of range", t.nsec)
+	}
+	if t.loc == nil {
+		add("location is nil")
+	}
+
+	if problems != nil {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
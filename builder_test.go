@@ -0,0 +1,108 @@
+package ptime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+func TestBuilder(t *testing.T) {
+	ti, err := NewBuilder(Iran()).Year(1394).Month(Mehr).Day(2).Hour(12).Minute(59).Second(59).Nanosecond(50260050).Build()
+	if err != nil {
+		t.Fatal(
+			"For", "1394/07/02 12:59:59",
+			"expected", nil,
+			"got", err,
+		)
+	}
+	if expected := Date(1394, Mehr, 2, 12, 59, 59, 50260050, Iran()); !ti.Time().Equal(expected.Time()) || ti.String() != expected.String() {
+		t.Error(
+			"For", "1394/07/02 12:59:59",
+			"expected", expected.String(),
+			"got", ti.String(),
+		)
+	}
+
+	ti, err = NewBuilder(Afghanistan()).Year(1403).Build()
+	if err != nil || ti.String() != "1403-01-01T00:00:00+04:30" {
+		t.Error(
+			"For", "1403",
+			"expected", "1403-01-01T00:00:00+04:30",
+			"got", ti.String(), err,
+		)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	builders := map[string]*Builder{
+		"no location":  NewBuilder(nil).Year(1403).Month(Mehr).Day(1),
+		"no year":      NewBuilder(Iran()).Month(Mehr).Day(1),
+		"month":        NewBuilder(Iran()).Year(1403).Month(13),
+		"day":          NewBuilder(Iran()).Year(1404).Month(Esfand).Day(30),
+		"day zero":     NewBuilder(Iran()).Year(1403).Day(0),
+		"hour":         NewBuilder(Iran()).Year(1403).Hour(24),
+		"minute":       NewBuilder(Iran()).Year(1403).Minute(-1),
+		"second":       NewBuilder(Iran()).Year(1403).Second(60),
+		"nanosecond":   NewBuilder(Iran()).Year(1403).Nanosecond(1e9),
+		"nil location": NewBuilder(Iran()).Year(1403).Location(nil),
+	}
+
+	for name, b := range builders {
+		if ti, err := b.Build(); err == nil {
+			t.Error(
+				"For", name,
+				"expected", "error",
+				"got", ti.String(),
+			)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, ti := range []Time{Now(Iran()), Date(1403, Esfand, 30, 23, 59, 59, 999999999, Iran()), New(time.Time{})} {
+		if err := ti.Validate(); err != nil {
+			t.Error(
+				"For", ti.String(),
+				"expected", nil,
+				"got", err,
+			)
+		}
+	}
+
+	err := Time{}.Validate()
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatal(
+			"For", "Time{}",
+			"expected", "*ValidationError",
+			"got", err,
+		)
+	}
+
+	expected := []string{"month 0 out of range", "day 0 out of range", "location is nil"}
+	if len(verr.Problems) != len(expected) {
+		t.Fatal(
+			"For", "Time{}",
+			"expected", expected,
+			"got", verr.Problems,
+		)
+	}
+	for i, p := range expected {
+		if verr.Problems[i] != p {
+			t.Error(
+				"For", "Time{}",
+				"expected", p,
+				"got", verr.Problems[i],
+			)
+		}
+	}
+
+	if s := err.Error(); s != "ptime: invalid time: month 0 out of range; day 0 out of range; location is nil" {
+		t.Error(
+			"For", "Time{}",
+			"expected", "ptime: invalid time: month 0 out of range; day 0 out of range; location is nil",
+			"got", s,
+		)
+	}
+}
@@ -0,0 +1,214 @@
+package ptime
+
+import "time"
+
+// CountWeekdays returns the number of days falling on the weekday wd
+// between the dates of start and end, both inclusive.
+//
+// Only the calendar dates of start and end are considered and the
+// result is 0 if end is before start.
+func CountWeekdays(start, end Time, wd Weekday) int {
+	from, to := start.jdn(), end.jdn()
+	if to < from {
+		return 0
+	}
+
+	total := to - from + 1
+	count := total / 7
+	if divider(int(wd-jdnWeekday(from)), 7) < total%7 {
+		count++
+	}
+	return count
+}
+
+// WeekdayCountsInMonth returns the number of days of the month of the year falling on
+// each weekday, indexed by Weekday from Shanbeh to Jomeh.
+// It panics if month is not in the range [Farvardin, Esfand].
+func WeekdayCountsInMonth(year int, month Month) [7]int {
+	if month < Farvardin || month > Esfand {
+		panic("ptime: month out of range in call to WeekdayCountsInMonth")
+	}
+
+	n, first := monthLength(year, month), jdnWeekday(getJdn(year, int(month), 1))
+	var counts [7]int
+	for wd := range counts {
+		counts[wd] = n / 7
+		if divider(wd-int(first), 7) < n%7 {
+			counts[wd]++
+		}
+	}
+	return counts
+}
+
+// RemainingWeekdays returns the number of days falling on the weekday wd
+// after the day of t until the end of its month, inclusive.
+func (t Time) RemainingWeekdays(wd Weekday) int {
+	return CountWeekdays(t.Tomorrow(), t.EndOfMonth(), wd)
+}
+
+// CountBusinessDays returns the number of working days between the dates
+// of start and end, both inclusive. Jomeh and the registered holidays
+// (see RegisterHoliday) are considered as the days off.
+//
+// The result is 0 if end is before start.
+func CountBusinessDays(start, end Time) int {
+	from, to := start.jdn(), end.jdn()
+	if to < from {
+		return 0
+	}
+
+	count := to - from + 1 - CountWeekdays(start, end, Jomeh)
+	for _, jdn := range holidayJdns(from, to) {
+		if jdnWeekday(jdn) != Jomeh {
+			count--
+		}
+	}
+	return count
+}
+
+// NextBusinessDay returns the first working day at or after the day of t, keeping the clock
+// and the location of t. Jomeh and the registered holidays (see RegisterHoliday) are skipped.
+func (t Time) NextBusinessDay() Time {
+	return t.snapBusinessDay(1)
+}
+
+// PreviousBusinessDay returns the last working day at or before the day of t, keeping the clock
+// and the location of t. Jomeh and the registered holidays (see RegisterHoliday) are skipped.
+func (t Time) PreviousBusinessDay() Time {
+	return t.snapBusinessDay(-1)
+}
+
+// AddBusinessDays returns the n-th working day after the day of t, or the -n-th working day
+// before it if n is negative, keeping the clock and the location of t. Jomeh and the registered
+// holidays (see RegisterHoliday) are skipped. The day of t itself is not counted, so it does
+// not need to be a working day.
+//
+// AddBusinessDays returns t if n is 0.
+func (t Time) AddBusinessDays(n int) Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+
+	jdn := t.jdn()
+	for ; n > 0; n-- {
+		jdn += step
+		for !isBusinessDay(jdn) {
+			jdn += step
+		}
+	}
+	return t.AddDate(0, 0, jdn-t.jdn())
+}
+
+// BusinessDaysBefore returns the n-th working day before the day of t, e.g. the due date
+// of 5 working days before a deadline. It is the same as AddBusinessDays(-n).
+func (t Time) BusinessDaysBefore(n int) Time {
+	return t.AddBusinessDays(-n)
+}
+
+// snapBusinessDay returns the first working day from the day of t in the direction step.
+func (t Time) snapBusinessDay(step int) Time {
+	jdn := t.jdn()
+	for !isBusinessDay(jdn) {
+		jdn += step
+	}
+	return t.AddDate(0, 0, jdn-t.jdn())
+}
+
+// isBusinessDay reports whether the Julian day number jdn is neither a Jomeh nor a registered holiday.
+func isBusinessDay(jdn int) bool {
+	return jdnWeekday(jdn) != Jomeh && len(holidayJdns(jdn, jdn)) == 0
+}
+
+// A WeekendConfig specifies the non-working days of the week.
+type WeekendConfig struct {
+	// Days maps each weekend day to the fraction of the day which is off,
+	// e.g. 1 for a full day off and 0.5 for a half working day.
+	// If Days is nil, Jomeh is the only weekend day.
+	Days map[Weekday]float64
+}
+
+// off returns the fraction of the weekday wd which is off.
+func (c WeekendConfig) off(wd Weekday) float64 {
+	if c.Days == nil {
+		if wd == Jomeh {
+			return 1
+		}
+		return 0
+	}
+	return c.Days[wd]
+}
+
+// IsWeekend reports whether the weekday wd is fully or partly off in c.
+func (c WeekendConfig) IsWeekend(wd Weekday) bool {
+	return c.off(wd) > 0
+}
+
+// IsWeekend reports whether d is a weekend day, i.e. Jomeh.
+// Use WeekendConfig.IsWeekend for a different definition of the weekend.
+func (d Weekday) IsWeekend() bool {
+	return WeekendConfig{}.IsWeekend(d)
+}
+
+// BusinessHoursBetween returns the number of working days, including the fractions
+// of half working days, between the dates of start and end, both inclusive.
+// The registered holidays (see RegisterHoliday) are considered as full days off.
+//
+// The result is 0 if end is before start.
+func BusinessHoursBetween(start, end Time, cfg WeekendConfig) float64 {
+	var days float64
+	for wd := Shanbeh; wd <= Jomeh; wd++ {
+		days += float64(CountWeekdays(start, end, wd)) * (1 - cfg.off(wd))
+	}
+	for _, jdn := range holidayJdns(start.jdn(), end.jdn()) {
+		days -= 1 - cfg.off(jdnWeekday(jdn))
+	}
+	return days
+}
+
+// A Clock is a time of day in hours and minutes (e.g. Clock{Hour: 8, Minute: 30} for 08:30).
+type Clock struct {
+	Hour, Minute int
+}
+
+// WorkingDuration returns the part of the duration between the instants of start and end
+// which falls within the daily working hours from dayStart to dayEnd on the working days,
+// where end is converted to the location of start. The days falling on a weekday in weekend
+// and the registered holidays (see RegisterHoliday) are not working days.
+//
+// The result is 0 if end is before start or dayEnd is not after dayStart.
+func WorkingDuration(start, end Time, dayStart, dayEnd Clock, weekend []Weekday) time.Duration {
+	s, e := start.Time(), end.Time()
+	end = New(e.In(s.Location()))
+
+	var d time.Duration
+	for jdn := start.jdn(); jdn <= end.jdn(); jdn++ {
+		if containsWeekday(weekend, jdnWeekday(jdn)) || len(holidayJdns(jdn, jdn)) > 0 {
+			continue
+		}
+
+		y, m, day := getDate(jdn)
+		from := Date(y, Month(m), day, dayStart.Hour, dayStart.Minute, 0, 0, start.loc).Time()
+		to := Date(y, Month(m), day, dayEnd.Hour, dayEnd.Minute, 0, 0, start.loc).Time()
+		if from.Before(s) {
+			from = s
+		}
+		if to.After(e) {
+			to = e
+		}
+		if to.After(from) {
+			d += to.Sub(from)
+		}
+	}
+	return d
+}
+
+// containsWeekday reports whether wd is one of the weekdays in wds.
+func containsWeekday(wds []Weekday, wd Weekday) bool {
+	for _, w := range wds {
+		if w == wd {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,368 @@
+package ptime_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+type weekdayRange struct {
+	start pdate
+	end   pdate
+}
+
+var weekdayRanges = []weekdayRange{
+	{pdate{1394, Mehr, 2}, pdate{1394, Mehr, 2}},
+	{pdate{1394, Mehr, 2}, pdate{1394, Azar, 17}},
+	{pdate{1394, Bahman, 20}, pdate{1395, Ordibehesht, 3}},
+	{pdate{1395, Farvardin, 1}, pdate{1395, Esfand, 30}},
+	{pdate{1396, Farvardin, 1}, pdate{1396, Esfand, 29}},
+}
+
+func TestCountWeekdays(t *testing.T) {
+	for _, r := range weekdayRanges {
+		start := Date(r.start.year, r.start.month, r.start.day, 12, 0, 0, 0, Iran())
+		end := Date(r.end.year, r.end.month, r.end.day, 8, 0, 0, 0, Iran())
+
+		var expected [7]int
+		for d := start; ; d = d.Tomorrow() {
+			expected[d.Weekday()]++
+			if y, m, dd := d.Date(); y == r.end.year && m == r.end.month && dd == r.end.day {
+				break
+			}
+		}
+
+		business := 0
+		for wd := Shanbeh; wd <= Jomeh; wd++ {
+			if c := CountWeekdays(start, end, wd); c != expected[wd] {
+				t.Error(
+					"For", fmt.Sprintf("CountWeekdays(%s, %s, %s)", start.Format("yyyy/MM/dd"), end.Format("yyyy/MM/dd"), wd),
+					"expected", expected[wd],
+					"got", c,
+				)
+			}
+			if wd != Jomeh {
+				business += expected[wd]
+			}
+		}
+
+		if c := CountBusinessDays(start, end); c != business {
+			t.Error(
+				"For", fmt.Sprintf("CountBusinessDays(%s, %s)", start.Format("yyyy/MM/dd"), end.Format("yyyy/MM/dd")),
+				"expected", business,
+				"got", c,
+			)
+		}
+	}
+}
+
+func TestCountWeekdaysFullYear(t *testing.T) {
+	start := Date(1395, Farvardin, 1, 0, 0, 0, 0, Iran())
+	end := Date(1395, Esfand, 30, 0, 0, 0, 0, Iran())
+
+	if c := CountWeekdays(start, end, Jomeh); c != 52 {
+		t.Error(
+			"For", "CountWeekdays(1395/01/01, 1395/12/30, Jomeh)",
+			"expected", 52,
+			"got", c,
+		)
+	}
+
+	if c := CountBusinessDays(start, end); c != 314 {
+		t.Error(
+			"For", "CountBusinessDays(1395/01/01, 1395/12/30)",
+			"expected", 314,
+			"got", c,
+		)
+	}
+
+	if c := CountBusinessDays(end, start); c != 0 {
+		t.Error(
+			"For", "CountBusinessDays(1395/12/30, 1395/01/01)",
+			"expected", 0,
+			"got", c,
+		)
+	}
+}
+
+func TestBusinessHoursBetween(t *testing.T) {
+	start := Date(1394, Mehr, 1, 0, 0, 0, 0, Iran())
+	end := Date(1394, Mehr, 30, 0, 0, 0, 0, Iran())
+
+	vals := []struct {
+		cfg      WeekendConfig
+		expected float64
+	}{
+		{WeekendConfig{}, 26},
+		{WeekendConfig{Days: map[Weekday]float64{Jomeh: 1}}, 26},
+		{WeekendConfig{Days: map[Weekday]float64{Panjshanbeh: 0.5, Jomeh: 1}}, 23.5},
+		{WeekendConfig{Days: map[Weekday]float64{Panjshanbeh: 1, Jomeh: 1}}, 21},
+		{WeekendConfig{Days: map[Weekday]float64{}}, 30},
+	}
+
+	for _, v := range vals {
+		if d := BusinessHoursBetween(start, end, v.cfg); d != v.expected {
+			t.Error(
+				"For", fmt.Sprintf("BusinessHoursBetween(1394/07/01, 1394/07/30, %v)", v.cfg.Days),
+				"expected", v.expected,
+				"got", d,
+			)
+		}
+	}
+
+	if d := BusinessHoursBetween(end, start, WeekendConfig{}); d != 0 {
+		t.Error(
+			"For", "BusinessHoursBetween(1394/07/30, 1394/07/01)",
+			"expected", 0,
+			"got", d,
+		)
+	}
+}
+
+func TestNextBusinessDay(t *testing.T) {
+	RegisterHoliday(IranHolidays()...)
+	defer ClearHolidays()
+
+	// 1403/01/01 to 1403/01/04 are holidays and 1403/01/03 is a Jomeh.
+	vals := []struct {
+		date       pdate
+		next, prev pdate
+	}{
+		{pdate{1403, Farvardin, 2}, pdate{1403, Farvardin, 5}, pdate{1402, Esfand, 28}},
+		{pdate{1403, Farvardin, 10}, pdate{1403, Farvardin, 11}, pdate{1403, Farvardin, 9}},
+		{pdate{1403, Farvardin, 13}, pdate{1403, Farvardin, 14}, pdate{1403, Farvardin, 11}},
+		{pdate{1403, Farvardin, 14}, pdate{1403, Farvardin, 14}, pdate{1403, Farvardin, 14}},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.date.year, v.date.month, v.date.day, 14, 30, 0, 0, Iran())
+		for _, c := range []struct {
+			got      Time
+			expected pdate
+		}{{ti.NextBusinessDay(), v.next}, {ti.PreviousBusinessDay(), v.prev}} {
+			if c.got.Year() != c.expected.year || c.got.Month() != c.expected.month || c.got.Day() != c.expected.day || c.got.Hour() != 14 || c.got.Minute() != 30 {
+				t.Error(
+					"For", ti.String(),
+					"expected", c.expected,
+					"got", c.got.String(),
+				)
+			}
+		}
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	RegisterHoliday(IranHolidays()...)
+	defer ClearHolidays()
+
+	// 1403/01/01 to 1403/01/04, 1403/01/12, 1403/01/13 and 1402/12/29 are holidays,
+	// and 1403/01/03 and 1403/01/10 are Jomehs.
+	vals := []struct {
+		date     pdate
+		n        int
+		expected pdate
+	}{
+		{pdate{1403, Farvardin, 11}, 1, pdate{1403, Farvardin, 14}},
+		{pdate{1403, Farvardin, 9}, 1, pdate{1403, Farvardin, 11}},
+		{pdate{1402, Esfand, 28}, 1, pdate{1403, Farvardin, 5}},
+		{pdate{1403, Farvardin, 3}, 1, pdate{1403, Farvardin, 5}},
+		{pdate{1403, Farvardin, 5}, 7, pdate{1403, Farvardin, 15}},
+		{pdate{1403, Farvardin, 11}, 0, pdate{1403, Farvardin, 11}},
+		{pdate{1403, Farvardin, 10}, 0, pdate{1403, Farvardin, 10}},
+		{pdate{1403, Farvardin, 14}, -1, pdate{1403, Farvardin, 11}},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.date.year, v.date.month, v.date.day, 14, 30, 0, 0, Iran())
+		d := ti.AddBusinessDays(v.n)
+		if d.Year() != v.expected.year || d.Month() != v.expected.month || d.Day() != v.expected.day || d.Hour() != 14 || d.Minute() != 30 {
+			t.Error(
+				"For", fmt.Sprintf("%s + %d", ti.String(), v.n),
+				"expected", v.expected,
+				"got", d.String(),
+			)
+		}
+	}
+}
+
+func TestBusinessDaysBefore(t *testing.T) {
+	// 1403/01/10 is a Jomeh.
+	ti := Date(1403, Farvardin, 11, 9, 0, 0, 0, Iran())
+	if d := ti.BusinessDaysBefore(1); d.Day() != 9 || d.Weekday() != Panjshanbeh {
+		t.Error(
+			"For", "1403/01/11 - 1",
+			"expected", "1403/01/09",
+			"got", d.String(),
+		)
+	}
+
+	RegisterHoliday(IranHolidays()...)
+	defer ClearHolidays()
+
+	vals := []struct {
+		date     pdate
+		n        int
+		expected pdate
+	}{
+		{pdate{1403, Farvardin, 11}, 1, pdate{1403, Farvardin, 9}},
+		{pdate{1403, Farvardin, 14}, 1, pdate{1403, Farvardin, 11}},
+		{pdate{1403, Farvardin, 14}, 2, pdate{1403, Farvardin, 9}},
+		{pdate{1403, Farvardin, 14}, 3, pdate{1403, Farvardin, 8}},
+		{pdate{1403, Farvardin, 6}, 2, pdate{1402, Esfand, 28}},
+		{pdate{1403, Farvardin, 18}, 5, pdate{1403, Farvardin, 9}},
+		{pdate{1403, Farvardin, 6}, 0, pdate{1403, Farvardin, 6}},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.date.year, v.date.month, v.date.day, 9, 0, 0, 0, Iran())
+		d := ti.BusinessDaysBefore(v.n)
+		if d.Year() != v.expected.year || d.Month() != v.expected.month || d.Day() != v.expected.day || d.Hour() != 9 {
+			t.Error(
+				"For", fmt.Sprintf("%s - %d", ti.String(), v.n),
+				"expected", v.expected,
+				"got", d.String(),
+			)
+		}
+	}
+}
+
+func TestWeekdayCountsInMonth(t *testing.T) {
+	// 1403/01/01 is a Charshanbeh, 1403/12/01 is a Charshanbeh and 1404/12/01 is a Jomeh.
+	vals := []struct {
+		year     int
+		month    Month
+		expected [7]int
+	}{
+		{1403, Farvardin, [7]int{4, 4, 4, 4, 5, 5, 5}},
+		{1403, Mehr, [7]int{4, 5, 5, 4, 4, 4, 4}},
+		{1403, Esfand, [7]int{4, 4, 4, 4, 5, 5, 4}},
+		{1404, Esfand, [7]int{4, 4, 4, 4, 4, 4, 5}},
+	}
+
+	for _, v := range vals {
+		counts := WeekdayCountsInMonth(v.year, v.month)
+		if counts != v.expected {
+			t.Error(
+				"For", fmt.Sprintf("%d %s", v.year, v.month),
+				"expected", v.expected,
+				"got", counts,
+			)
+		}
+
+		start := Date(v.year, v.month, 1, 0, 0, 0, 0, Iran())
+		for wd := Shanbeh; wd <= Jomeh; wd++ {
+			if n := CountWeekdays(start, start.EndOfMonth(), wd); n != counts[wd] {
+				t.Error(
+					"For", fmt.Sprintf("%d %s %s", v.year, v.month, wd),
+					"expected", n,
+					"got", counts[wd],
+				)
+			}
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error(
+					"For", "WeekdayCountsInMonth(1403, 13)",
+					"expected", "panic",
+					"got", nil,
+				)
+			}
+		}()
+		WeekdayCountsInMonth(1403, 13)
+	}()
+}
+
+func TestRemainingWeekdays(t *testing.T) {
+	// 1403/12/01 is a Charshanbeh and Esfand 1403 has 30 days.
+	vals := []struct {
+		date  pdate
+		wd    Weekday
+		count int
+	}{
+		{pdate{1403, Esfand, 1}, Jomeh, 4},
+		{pdate{1403, Esfand, 1}, Charshanbeh, 4},
+		{pdate{1403, Esfand, 1}, Panjshanbeh, 5},
+		{pdate{1403, Esfand, 15}, Jomeh, 2},
+		{pdate{1403, Esfand, 29}, Panjshanbeh, 1},
+		{pdate{1403, Esfand, 30}, Panjshanbeh, 0},
+		{pdate{1402, Esfand, 28}, Seshanbeh, 1},
+		{pdate{1402, Esfand, 29}, Seshanbeh, 0},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.date.year, v.date.month, v.date.day, 10, 0, 0, 0, Iran())
+		if n := ti.RemainingWeekdays(v.wd); n != v.count {
+			t.Error(
+				"For", fmt.Sprintf("%s from %s", v.wd, ti.String()),
+				"expected", v.count,
+				"got", n,
+			)
+		}
+	}
+}
+
+func TestIsWeekend(t *testing.T) {
+	twoDays := WeekendConfig{Days: map[Weekday]float64{Panjshanbeh: 1, Jomeh: 1}}
+	for wd := Shanbeh; wd <= Jomeh; wd++ {
+		if w := wd.IsWeekend(); w != (wd == Jomeh) {
+			t.Error(
+				"For", wd,
+				"expected", wd == Jomeh,
+				"got", w,
+			)
+		}
+		if w := twoDays.IsWeekend(wd); w != (wd == Panjshanbeh || wd == Jomeh) {
+			t.Error(
+				"For", wd, "with a two-day weekend",
+				"expected", !w,
+				"got", w,
+			)
+		}
+	}
+}
+
+func TestWorkingDuration(t *testing.T) {
+	// 1403/12/01 is a Charshanbeh.
+	day := func(d, hour, min int) Time {
+		return Date(1403, Esfand, d, hour, min, 0, 0, Iran())
+	}
+	weekend := []Weekday{Panjshanbeh, Jomeh}
+	vals := []struct {
+		start, end       Time
+		dayStart, dayEnd Clock
+		expected         time.Duration
+	}{
+		{day(1, 10, 30), day(6, 11, 15), Clock{8, 0}, Clock{16, 0}, 24*time.Hour + 45*time.Minute},
+		{day(1, 7, 0), day(1, 9, 0), Clock{8, 0}, Clock{16, 0}, time.Hour},
+		{day(1, 15, 0), day(1, 17, 0), Clock{8, 0}, Clock{16, 0}, time.Hour},
+		{day(1, 17, 0), day(4, 7, 0), Clock{8, 0}, Clock{16, 0}, 0},
+		{day(2, 9, 0), day(3, 12, 0), Clock{8, 0}, Clock{16, 0}, 0},
+		{day(1, 8, 0), day(1, 16, 0), Clock{8, 30}, Clock{14, 0}, 5*time.Hour + 30*time.Minute},
+		{day(6, 11, 15), day(1, 10, 30), Clock{8, 0}, Clock{16, 0}, 0},
+		{day(1, 10, 30), day(6, 11, 15), Clock{16, 0}, Clock{8, 0}, 0},
+		{day(1, 10, 30), Date(1403, Esfand, 6, 7, 45, 0, 0, time.UTC), Clock{8, 0}, Clock{16, 0}, 24*time.Hour + 45*time.Minute},
+	}
+
+	for _, v := range vals {
+		if d := WorkingDuration(v.start, v.end, v.dayStart, v.dayEnd, weekend); d != v.expected {
+			t.Error(
+				"For", v.start.String(), "to", v.end.String(),
+				"expected", v.expected,
+				"got", d,
+			)
+		}
+	}
+
+	if d := WorkingDuration(day(1, 10, 30), day(6, 11, 15), Clock{8, 0}, Clock{16, 0}, nil); d != 40*time.Hour+45*time.Minute {
+		t.Error(
+			"For", "no weekend",
+			"expected", 40*time.Hour+45*time.Minute,
+			"got", d,
+		)
+	}
+}
@@ -0,0 +1,38 @@
+package ptime
+
+import "time"
+
+// A Converter converts instances of time.Time to Time like New, but it caches the
+// Persian date of the last converted day, so converting many instants within the
+// same day only computes their clocks.
+//
+// The zero value of Converter is ready to use. A Converter must not be used concurrently.
+type Converter struct {
+	loc   *time.Location
+	day   int64
+	valid bool
+	date  Time
+}
+
+// Convert returns a new instance of Time corresponding to the time of ti.
+// The result is the same as New(ti).
+func (c *Converter) Convert(ti time.Time) Time {
+	_, offset := ti.Zone()
+	sec := ti.Unix() + int64(offset)
+	day := sec / 86400
+	if sec%86400 < 0 {
+		day--
+	}
+
+	loc := ti.Location()
+	if !c.valid || c.day != day || c.loc != loc {
+		c.date = New(ti)
+		c.loc, c.day, c.valid = loc, day, true
+		return c.date
+	}
+
+	t := c.date
+	sec -= day * 86400
+	t.hour, t.min, t.sec, t.nsec = int(sec/3600), int(sec%3600/60), int(sec%60), ti.Nanosecond()
+	return t
+}
@@ -0,0 +1,57 @@
+package ptime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+func TestConverter(t *testing.T) {
+	var c Converter
+
+	for _, loc := range []*time.Location{Iran(), Afghanistan(), time.UTC, time.FixedZone("", -12600)} {
+		// The daylight saving time of Iran started on 2015-03-22 00:00.
+		for _, year := range []int{2015, 2025} {
+			start := time.Date(year, time.March, 19, 22, 0, 0, 123, loc)
+			for ti := start; ti.Before(start.Add(72 * time.Hour)); ti = ti.Add(17*time.Minute + 3*time.Second) {
+				p, expected := c.Convert(ti), New(ti)
+				if p.String() != expected.String() || p.Weekday() != expected.Weekday() || p.Location() != expected.Location() {
+					t.Error(
+						"For", ti,
+						"expected", expected.String(),
+						"got", p.String(),
+					)
+				}
+			}
+		}
+	}
+
+	// Instants before 1970 have negative unix timestamps.
+	for ti := time.Date(1960, time.March, 20, 23, 0, 0, 0, time.UTC); ti.Day() < 23; ti = ti.Add(time.Hour) {
+		if p, expected := c.Convert(ti), New(ti); p.String() != expected.String() {
+			t.Error(
+				"For", ti,
+				"expected", expected.String(),
+				"got", p.String(),
+			)
+		}
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	start := time.Date(2025, time.March, 21, 0, 0, 0, 0, Iran())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		New(start.Add(time.Duration(i%86400) * time.Second))
+	}
+}
+
+func BenchmarkConverter(b *testing.B) {
+	var c Converter
+	start := time.Date(2025, time.March, 21, 0, 0, 0, 0, Iran())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.Convert(start.Add(time.Duration(i%86400) * time.Second))
+	}
+}
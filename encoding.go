@@ -0,0 +1,250 @@
+package ptime
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// binaryVersion is the version of the layout produced by MarshalBinary.
+const binaryVersion byte = 2
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// The layout of the encoded data is as follows:
+//
+//	[0]       version of the layout (currently 2)
+//	[1:9]     seconds since January 1, 1970 UTC (big-endian int64)
+//	[9:13]    nanoseconds offset (big-endian int32)
+//	[13:17]   zone offset in seconds east of UTC (big-endian int32)
+//	[17]      length of the name of location (n)
+//	[18:18+n] the name of location
+//
+// The version 1 of the layout lacks the zone offset.
+func (t Time) MarshalBinary() ([]byte, error) {
+	name := t.Location().String()
+	if len(name) > 255 {
+		return nil, errors.New("ptime: Time.MarshalBinary: location name too long")
+	}
+
+	ti := t.Time()
+	_, offset := ti.Zone()
+
+	b := make([]byte, 18, 18+len(name))
+	b[0] = binaryVersion
+	binary.BigEndian.PutUint64(b[1:], uint64(ti.Unix()))
+	binary.BigEndian.PutUint32(b[9:], uint32(ti.Nanosecond()))
+	binary.BigEndian.PutUint32(b[13:], uint32(offset))
+	b[17] = byte(len(name))
+
+	return append(b, name...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+//
+// The location is loaded by its name. If it is not available (e.g. the time zone
+// database is missing), the name is empty (e.g. a time.FixedZone without a name) or
+// its offset differs from the encoded one, a fixed zone with the same name and the
+// encoded offset is used.
+func (t *Time) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("ptime: Time.UnmarshalBinary: no data")
+	}
+
+	var header int
+	switch data[0] {
+	case 1:
+		header = 14
+	case 2:
+		header = 18
+	default:
+		return errors.New("ptime: Time.UnmarshalBinary: unsupported version")
+	}
+
+	if len(data) < header || len(data) != header+int(data[header-1]) {
+		return errors.New("ptime: Time.UnmarshalBinary: invalid length")
+	}
+
+	sec := int64(binary.BigEndian.Uint64(data[1:]))
+	nsec := int64(int32(binary.BigEndian.Uint32(data[9:])))
+	name := string(data[header:])
+
+	ti := time.Unix(sec, nsec)
+	loc, err := time.LoadLocation(name)
+	if data[0] == 1 {
+		if err != nil {
+			return err
+		}
+	} else if offset := int(int32(binary.BigEndian.Uint32(data[13:]))); err != nil || name == "" || zoneOffset(ti, loc) != offset {
+		loc = time.FixedZone(name, offset)
+	}
+
+	t.SetTime(ti.In(loc))
+	return nil
+}
+
+// zoneOffset returns the offset in seconds east of UTC of ti in loc.
+func zoneOffset(ti time.Time, loc *time.Location) int {
+	_, offset := ti.In(loc).Zone()
+	return offset
+}
+
+// JSONLayout is the layout used by MarshalJSON and UnmarshalJSON (see Format and Parse).
+// The default layout is in the format of RFC 3339 with nanoseconds, e.g. 1394-07-02T12:59:59.050260050+03:30.
+//
+// JSONLayout is read without synchronization, so it should only be set during the
+// initialization of the program, before any time is marshaled or unmarshaled.
+var JSONLayout = "yyyy-MM-ddTHH:mm:ss.SSSZ"
+
+// MarshalJSON implements the json.Marshaler interface.
+// The time is a quoted string formatted by JSONLayout.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Format(JSONLayout))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The time is expected to be a quoted string formatted by JSONLayout and
+// a null value leaves t unchanged.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("ptime: Time.UnmarshalJSON: %v", err)
+	}
+
+	pt, err := Parse(JSONLayout, s)
+	if err != nil {
+		return err
+	}
+
+	*t = pt
+	return nil
+}
+
+// A ValueMode specifies the representation of Time returned by its Value method.
+type ValueMode int
+
+// List of value modes.
+const (
+	// ValueGregorian represents Time as a time.Time (the default).
+	ValueGregorian ValueMode = iota
+	// ValueJalaliDate represents Time as a Jalali date string in the format of yyyy/MM/dd.
+	ValueJalaliDate
+	// ValueJalaliDateTime represents Time as a Jalali string in the format of yyyy/MM/dd HH:mm:ss.
+	ValueJalaliDateTime
+)
+
+var valueMode = ValueGregorian
+
+// SetValueMode sets the representation of Time returned by its Value method.
+//
+// It is safe to call SetValueMode concurrently with other functions of the package.
+func SetValueMode(mode ValueMode) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	valueMode = mode
+}
+
+// Value implements the driver.Valuer interface.
+//
+// The returned value depends on the mode set by SetValueMode.
+func (t Time) Value() (driver.Value, error) {
+	configMu.RLock()
+	mode := valueMode
+	configMu.RUnlock()
+
+	switch mode {
+	case ValueJalaliDate:
+		return t.Format("yyyy/MM/dd"), nil
+	case ValueJalaliDateTime:
+		return t.Format("yyyy/MM/dd HH:mm:ss"), nil
+	}
+	return t.Time(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+//
+// src may be a time.Time, a unix timestamp in seconds (int64), nil or a string (or []byte) of
+// either a Gregorian time in the format of RFC3339, 2006-01-02 15:04:05 or 2006-01-02, or a
+// Jalali time in the format of yyyy/MM/dd, yyyy/MM/dd HH:mm or yyyy/MM/dd HH:mm:ss
+// where '-' may be used instead of '/'.
+//
+// Since a Jalali string may look like a Gregorian one (e.g. 1403-01-15), a string
+// is considered to be Jalali if its year is less than 1700. The timestamps and the times
+// without location are interpreted in the default location (see DefaultLocation).
+func (t *Time) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = Time{}
+		return nil
+	case time.Time:
+		t.SetTime(v)
+		return nil
+	case int64:
+		t.SetUnix(v, 0, DefaultLocation())
+		return nil
+	case []byte:
+		return t.scanString(string(v))
+	case string:
+		return t.scanString(v)
+	}
+	return fmt.Errorf("ptime: cannot scan %T into Time", src)
+}
+
+func (t *Time) scanString(s string) error {
+	if isJalali(s) {
+		date, clock := s, ""
+		if i := strings.IndexByte(s, ' '); i >= 0 {
+			date, clock = s[:i], s[i+1:]
+		}
+
+		loc := DefaultLocation()
+		pt, err := ParseSlash(date, loc)
+		if err != nil {
+			return err
+		}
+
+		if clock != "" {
+			layout := "HH:mm:ss"
+			if len(clock) == 5 {
+				layout = "HH:mm"
+			}
+
+			c, err := ParseInLocation(layout, clock, loc)
+			if err != nil {
+				return err
+			}
+			pt = Date(pt.year, pt.month, pt.day, c.hour, c.min, c.sec, 0, loc)
+		}
+
+		*t = pt
+		return nil
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"} {
+		if g, err := time.ParseInLocation(layout, s, DefaultLocation()); err == nil {
+			t.SetTime(g)
+			return nil
+		}
+	}
+	return fmt.Errorf("ptime: cannot scan %q into Time", s)
+}
+
+// isJalali reports whether s starts with a year less than 1700 followed by '/' or '-'.
+func isJalali(s string) bool {
+	i := strings.IndexAny(s, "/-")
+	if i <= 0 {
+		return false
+	}
+
+	year, err := strconv.Atoi(s[:i])
+	return err == nil && year < 1700
+}
@@ -0,0 +1,299 @@
+package ptime_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"time"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	ti := Date(1394, Mehr, 2, 12, 59, 59, 50260050, Iran())
+
+	b, err := ti.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b[0] != 2 {
+		t.Error(
+			"For", "MarshalBinary()[0]",
+			"expected", 2,
+			"got", b[0],
+		)
+	}
+
+	var u Time
+	if err := u.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+
+	if u.String() != ti.String() {
+		t.Error(
+			"For", "UnmarshalBinary()",
+			"expected", ti.String(),
+			"got", u.String(),
+		)
+	}
+
+	for _, b := range [][]byte{nil, {3}, {2}, {1, 0, 0}, append(b, 'x')} {
+		if err := u.UnmarshalBinary(b); err == nil {
+			t.Error(
+				"For", b,
+				"expected", "error",
+				"got", nil,
+			)
+		}
+	}
+}
+
+func TestUnmarshalBinaryV1(t *testing.T) {
+	b := []byte{1, 0, 0, 0, 0, 0x56, 0xae, 0x82, 0x96, 0, 0, 0, 0, 11}
+	b = append(b, "Asia/Tehran"...)
+
+	var ti Time
+	if err := ti.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+
+	if ti.String() != "1394-11-12T01:24:30+03:30" {
+		t.Error(
+			"For", "UnmarshalBinary(v1)",
+			"expected", "1394-11-12T01:24:30+03:30",
+			"got", ti.String(),
+		)
+	}
+}
+
+func TestUnmarshalBinaryFixedZone(t *testing.T) {
+	// The name of location is not available in the time zone database,
+	// which is the case for all locations on a machine without it.
+	ti := Date(1394, Mehr, 2, 12, 59, 59, 0, time.FixedZone("Nowhere/City", 12600))
+
+	b, err := ti.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var u Time
+	if err := u.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+
+	if name, offset := u.Zone(); name != "Nowhere/City" || offset != 12600 || u.String() != ti.String() {
+		t.Error(
+			"For", "UnmarshalBinary()",
+			"expected", ti.String(),
+			"got", u.String(),
+		)
+	}
+}
+
+func TestUnmarshalBinaryUnnamedZone(t *testing.T) {
+	for _, offset := range []int{18000, -12600} {
+		ti := Date(1403, Dey, 1, 12, 30, 0, 0, time.FixedZone("", offset))
+
+		b, err := ti.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var u Time
+		if err := u.UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, o := u.Zone(); o != offset || u.String() != ti.String() {
+			t.Error(
+				"For", offset,
+				"expected", ti.String(),
+				"got", u.String(),
+			)
+		}
+	}
+}
+
+func TestMarshalBinaryRandom(t *testing.T) {
+	vals := []Time{
+		Unix(1454277270, 0, Iran()),
+		Unix(-9999999999, 999999999, Afghanistan()),
+		Unix(1710880000, 50260050, time.UTC),
+	}
+
+	locs := []*time.Location{Iran(), Afghanistan(), time.UTC}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		vals = append(vals, Unix(r.Int63n(2e10)-1e10, r.Int63n(1e9), locs[r.Intn(len(locs))]))
+	}
+
+	for _, ti := range vals {
+		b, err := ti.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var u Time
+		if err := u.UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+
+		if u.UnixNano() != ti.UnixNano() || u.Unix() != ti.Unix() || u.String() != ti.String() || u.Location().String() != ti.Location().String() {
+			t.Error(
+				"expected", ti.String(),
+				"got", u.String(),
+			)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	vals := map[interface{}]string{
+		"2024-04-03T10:20:30+03:30": "1403/01/15 10:20:30",
+		"2024-04-03T06:50:30Z":      "1403/01/15 06:50:30",
+		"2024-04-03 10:20:30":       "1403/01/15 10:20:30",
+		"2024-04-03":                "1403/01/15 00:00:00",
+		"1403/01/15":                "1403/01/15 00:00:00",
+		"1403-01-15":                "1403/01/15 00:00:00",
+		"1403/1/15 10:20":           "1403/01/15 10:20:00",
+		"1403/01/15 10:20:30":       "1403/01/15 10:20:30",
+		int64(1712127030):           "1403/01/15 10:20:30",
+		time.Date(2024, 4, 3, 10, 20, 30, 0, Iran()): "1403/01/15 10:20:30",
+	}
+
+	for src, expected := range vals {
+		var ti Time
+		if err := ti.Scan(src); err != nil {
+			t.Error(
+				"For", src,
+				"expected", expected,
+				"got", err,
+			)
+			continue
+		}
+
+		if s := ti.Format("yyyy/MM/dd HH:mm:ss"); s != expected {
+			t.Error(
+				"For", src,
+				"expected", expected,
+				"got", s,
+			)
+		}
+	}
+
+	var ti Time
+	if err := ti.Scan([]byte("1403/01/15")); err != nil || ti.Format("yyyy/MM/dd") != "1403/01/15" {
+		t.Error(
+			"For", "[]byte(1403/01/15)",
+			"expected", "1403/01/15",
+			"got", ti.Format("yyyy/MM/dd"), err,
+		)
+	}
+
+	for _, src := range []interface{}{"1403/13/15", "yesterday", 3.5} {
+		if err := ti.Scan(src); err == nil {
+			t.Error(
+				"For", src,
+				"expected", "error",
+				"got", nil,
+			)
+		}
+	}
+}
+
+func TestValue(t *testing.T) {
+	ti := Date(1403, Farvardin, 15, 10, 20, 30, 0, Iran())
+	defer SetValueMode(ValueGregorian)
+
+	vals := map[ValueMode]interface{}{
+		ValueGregorian:      ti.Time(),
+		ValueJalaliDate:     "1403/01/15",
+		ValueJalaliDateTime: "1403/01/15 10:20:30",
+	}
+
+	for mode, expected := range vals {
+		SetValueMode(mode)
+		v, err := ti.Value()
+		if err != nil || v != expected {
+			t.Error(
+				"For", mode,
+				"expected", expected,
+				"got", v, err,
+			)
+		}
+
+		var u Time
+		if err := u.Scan(v); err != nil || u.Format("yyyy/MM/dd") != "1403/01/15" {
+			t.Error(
+				"For", v,
+				"expected", "1403/01/15",
+				"got", u.Format("yyyy/MM/dd"), err,
+			)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	ti := Date(1394, Mehr, 2, 12, 59, 59, 50260050, Iran())
+
+	b, err := json.Marshal(struct{ At Time }{ti})
+	if err != nil || string(b) != `{"At":"1394-07-02T12:59:59.050260050+03:30"}` {
+		t.Error(
+			"For", ti.String(),
+			"expected", `{"At":"1394-07-02T12:59:59.050260050+03:30"}`,
+			"got", string(b), err,
+		)
+	}
+
+	var v struct{ At Time }
+	if err := json.Unmarshal(b, &v); err != nil || !v.At.Time().Equal(ti.Time()) || v.At.String() != ti.String() {
+		t.Error(
+			"For", string(b),
+			"expected", ti.String(),
+			"got", v.At.String(), err,
+		)
+	}
+}
+
+func TestMarshalJSONLayout(t *testing.T) {
+	defer func(layout string) { JSONLayout = layout }(JSONLayout)
+	JSONLayout = "yyyy/MM/dd"
+
+	ti := Date(1403, Esfand, 30, 22, 10, 0, 0, Iran())
+	b, err := json.Marshal(ti)
+	if err != nil || string(b) != `"1403/12/30"` {
+		t.Error(
+			"For", ti.String(),
+			"expected", `"1403/12/30"`,
+			"got", string(b), err,
+		)
+	}
+
+	var u Time
+	if err := json.Unmarshal(b, &u); err != nil || u.String() != "1403-12-30T00:00:00+03:30" {
+		t.Error(
+			"For", string(b),
+			"expected", "1403-12-30T00:00:00+03:30",
+			"got", u.String(), err,
+		)
+	}
+
+	if err := json.Unmarshal([]byte("null"), &u); err != nil || u.Day() != 30 {
+		t.Error(
+			"For", "null",
+			"expected", u.String(),
+			"got", u.String(), err,
+		)
+	}
+
+	for _, data := range []string{`"1403-12-30"`, `"1404/12/30"`, `1403`, `"1403/12/30`} {
+		if err := json.Unmarshal([]byte(data), &u); err == nil {
+			t.Error(
+				"For", data,
+				"expected", "error",
+				"got", u.String(),
+			)
+		}
+	}
+}
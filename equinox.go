@@ -0,0 +1,105 @@
+package ptime
+
+import (
+	"math"
+	"time"
+)
+
+// equinoxTerms are the periodic terms (A, B, C) of the March equinox by Jean Meeus,
+// Astronomical Algorithms, chapter 27.
+var equinoxTerms = [24][3]float64{
+	{485, 324.96, 1934.136}, {203, 337.23, 32964.467}, {199, 342.08, 20.186},
+	{182, 27.85, 445267.112}, {156, 73.14, 45036.886}, {136, 171.52, 22518.443},
+	{77, 222.54, 65928.934}, {74, 296.72, 3034.906}, {70, 243.58, 9037.513},
+	{58, 119.81, 33718.147}, {52, 297.17, 150.678}, {50, 21.02, 2281.226},
+	{45, 247.54, 29929.562}, {44, 325.15, 31555.956}, {29, 60.93, 4443.417},
+	{18, 155.12, 67555.328}, {17, 288.79, 4562.452}, {16, 198.04, 62894.029},
+	{14, 199.76, 31436.921}, {12, 95.39, 14577.848}, {12, 287.11, 31931.756},
+	{12, 320.81, 34777.259}, {9, 227.73, 1222.114}, {8, 15.45, 16859.074},
+}
+
+// IsLeapAstronomical reports whether the year of t has 366 days by the astronomical rule,
+// i.e. there are 366 days between the Nowruz of the year and the Nowruz of the next year.
+//
+// Nowruz is the day in the location loc on which the March equinox occurs if it occurs
+// before 12:00:00, or the next day otherwise. The official calendar of Iran uses this rule
+// whereas IsLeap uses the 33-year arithmetic rule, so they differ in some years.
+//
+// loc is a pointer to time.Location and must not be nil.
+func (t Time) IsLeapAstronomical(loc *time.Location) bool {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to IsLeapAstronomical")
+	}
+
+	return astronomicalNowruz(t.year+1, loc)-astronomicalNowruz(t.year, loc) == 366
+}
+
+// LeapYearsAstronomical is like LeapYears but uses the astronomical rule of IsLeapAstronomical
+// with Nowruz determined in the location loc.
+//
+// loc is a pointer to time.Location and must not be nil.
+func LeapYearsAstronomical(from, to int, loc *time.Location) []int {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to LeapYearsAstronomical")
+	}
+
+	var years []int
+	for y, nowruz := from, astronomicalNowruz(from, loc); y <= to; y++ {
+		next := astronomicalNowruz(y+1, loc)
+		if next-nowruz == 366 {
+			years = append(years, y)
+		}
+		nowruz = next
+	}
+	return years
+}
+
+// MarchEquinox returns the instant of the March equinox of the Gregorian year in UTC.
+// The result is accurate to a few minutes for the years 1000 to 3000.
+func MarchEquinox(year int) time.Time {
+	var jde0 float64
+	if year < 1000 {
+		y := float64(year) / 1000
+		jde0 = 1721139.29189 + 365242.13740*y + 0.06134*y*y + 0.00111*y*y*y - 0.00071*y*y*y*y
+	} else {
+		y := float64(year-2000) / 1000
+		jde0 = 2451623.80984 + 365242.37404*y + 0.05169*y*y - 0.00411*y*y*y - 0.00057*y*y*y*y
+	}
+
+	rad := math.Pi / 180
+	c := (jde0 - 2451545) / 36525
+	w := (35999.373*c - 2.47) * rad
+	l := 1 + 0.0334*math.Cos(w) + 0.0007*math.Cos(2*w)
+
+	var s float64
+	for _, term := range equinoxTerms {
+		s += term[0] * math.Cos((term[1]+term[2]*c)*rad)
+	}
+
+	jde := jde0 + 0.00001*s/l
+	sec := (jde-2440587.5)*86400 - deltaT(year)
+	whole := math.Floor(sec)
+	return time.Unix(int64(whole), int64((sec-whole)*1e9)).UTC()
+}
+
+// deltaT returns the approximate difference between the Terrestrial Time and
+// the Universal Time in seconds in the Gregorian year.
+func deltaT(year int) float64 {
+	if year >= 2005 && year <= 2050 {
+		t := float64(year - 2000)
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	}
+	u := float64(year-1820) / 100
+	return -20 + 32*u*u
+}
+
+// astronomicalNowruz returns the Julian day number of the Nowruz of the year
+// by the astronomical rule in the location loc.
+func astronomicalNowruz(year int, loc *time.Location) int {
+	e := MarchEquinox(year + 621).In(loc)
+	jdn := gregorianJdn(e.Year(), int(e.Month()), e.Day())
+	if e.Hour() >= 12 {
+		jdn++
+	}
+	return jdn
+}
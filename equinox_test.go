@@ -0,0 +1,86 @@
+package ptime_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+func TestMarchEquinox(t *testing.T) {
+	equinoxes := map[int]time.Time{
+		2024: time.Date(2024, time.March, 20, 3, 6, 0, 0, time.UTC),
+		2025: time.Date(2025, time.March, 20, 9, 1, 0, 0, time.UTC),
+		2026: time.Date(2026, time.March, 20, 14, 46, 0, 0, time.UTC),
+	}
+
+	for year, expected := range equinoxes {
+		if d := MarchEquinox(year).Sub(expected); d < -time.Minute || d > time.Minute {
+			t.Error(
+				"For", year,
+				"expected", expected,
+				"got", MarchEquinox(year),
+			)
+		}
+	}
+}
+
+func TestIsLeapAstronomical(t *testing.T) {
+	irst := time.FixedZone("IRST", 12600)
+
+	// The 33-year rule agrees with the astronomical rule from 1178 to 1633.
+	years := map[int]bool{
+		1176: true,
+		1177: false,
+		1178: false,
+		1399: true,
+		1403: true,
+		1404: false,
+		1407: false,
+		1408: true,
+		1634: false,
+		1635: true,
+	}
+
+	for year, expected := range years {
+		ti := Date(year, Mehr, 1, 0, 0, 0, 0, time.UTC)
+		if leap := ti.IsLeapAstronomical(irst); leap != expected {
+			t.Error(
+				"For", year,
+				"expected", expected,
+				"got", leap,
+			)
+		}
+	}
+
+	for _, year := range []int{1177, 1634, 1635} {
+		ti := Date(year, Mehr, 1, 0, 0, 0, 0, time.UTC)
+		if ti.IsLeap() == ti.IsLeapAstronomical(irst) {
+			t.Error(
+				"For", year,
+				"expected", "the rules to differ",
+				"got", ti.IsLeap(),
+			)
+		}
+	}
+}
+
+func TestLeapYearsAstronomical(t *testing.T) {
+	irst := time.FixedZone("IRST", 12600)
+	vals := map[[2]int]string{
+		{1395, 1410}: "[1395 1399 1403 1408]",
+		{1170, 1180}: "[1172 1176]",
+		{1625, 1645}: "[1626 1630 1635 1639 1643]",
+	}
+
+	for r, expected := range vals {
+		if years := fmt.Sprint(LeapYearsAstronomical(r[0], r[1], irst)); years != expected {
+			t.Error(
+				"For", r,
+				"expected", expected,
+				"got", years,
+			)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package ptime
+
+import "time"
+
+// An EventRule specifies the day of a movable event in each year.
+//
+// The day is found in three steps: the anchor day is Day of Month, then if Nth is
+// non-zero, the day moves to the Nth Weekday at or after the anchor day (or the -Nth
+// Weekday at or before it if Nth is negative), and finally Offset days are added.
+type EventRule struct {
+	Month Month
+	// Day is the day of Month. A negative Day counts from the end of the month,
+	// e.g. -1 is the last day. A day which does not exist in a non-leap Esfand
+	// is clamped to the first or the last day.
+	Day     int
+	Weekday Weekday
+	Nth     int
+	Offset  int
+}
+
+// List of predefined event rules.
+var (
+	// SizdahBedar is the 13th day of the year, 12 days after Nowruz.
+	SizdahBedar = EventRule{Month: Farvardin, Day: 1, Offset: 12}
+	// ChaharshanbehSuri is the day before the last Charshanbeh of the year.
+	ChaharshanbehSuri = EventRule{Month: Esfand, Day: -1, Weekday: Charshanbeh, Nth: -1, Offset: -1}
+)
+
+// ComputeEvent returns the day of the event specified by rule in the year at 00:00:00
+// in the location loc, e.g. the first Shanbeh after Farvardin 15 is
+//
+//	ComputeEvent(year, EventRule{Month: Farvardin, Day: 16, Weekday: Shanbeh, Nth: 1}, loc)
+//
+// It panics if rule.Month or rule.Day is out of range.
+//
+// loc is a pointer to time.Location and must not be nil.
+func ComputeEvent(year int, rule EventRule, loc *time.Location) Time {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to ComputeEvent")
+	}
+
+	day := rule.Day
+	if day < 0 && rule.Month >= Farvardin && rule.Month <= Esfand && -day <= rule.Month.Length(true) {
+		if day += monthLength(year, rule.Month) + 1; day < 1 {
+			day = 1
+		}
+	}
+	jdn := getJdn(year, int(rule.Month), occurrenceDay(year, rule.Month, day, "ComputeEvent"))
+
+	switch {
+	case rule.Nth > 0:
+		jdn += divider(int(rule.Weekday-jdnWeekday(jdn)), 7) + 7*(rule.Nth-1)
+	case rule.Nth < 0:
+		jdn -= divider(int(jdnWeekday(jdn)-rule.Weekday), 7) - 7*(rule.Nth+1)
+	}
+	jdn += rule.Offset
+
+	y, m, d := getDate(jdn)
+	return Date(y, Month(m), d, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,59 @@
+package ptime_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+func TestComputeEvent(t *testing.T) {
+	// 1403/01/01 is a Charshanbeh, 1403/12/30 is a Panjshanbeh and 1404/12/29 is a Jomeh.
+	vals := []struct {
+		year     int
+		rule     EventRule
+		expected pdate
+	}{
+		{1403, SizdahBedar, pdate{1403, Farvardin, 13}},
+		{1403, ChaharshanbehSuri, pdate{1403, Esfand, 28}},
+		{1404, ChaharshanbehSuri, pdate{1404, Esfand, 26}},
+		{1403, EventRule{Month: Farvardin, Day: 16, Weekday: Shanbeh, Nth: 1}, pdate{1403, Farvardin, 18}},
+		{1403, EventRule{Month: Farvardin, Day: 1, Weekday: Charshanbeh, Nth: 1}, pdate{1403, Farvardin, 1}},
+		{1403, EventRule{Month: Farvardin, Day: 1, Weekday: Charshanbeh, Nth: 2}, pdate{1403, Farvardin, 8}},
+		{1403, EventRule{Month: Farvardin, Day: 1, Weekday: Jomeh, Nth: 3}, pdate{1403, Farvardin, 17}},
+		{1403, EventRule{Month: Esfand, Day: -1, Weekday: Jomeh, Nth: -1}, pdate{1403, Esfand, 24}},
+		{1403, EventRule{Month: Esfand, Day: -1, Weekday: Panjshanbeh, Nth: -2}, pdate{1403, Esfand, 23}},
+		{1404, EventRule{Month: Esfand, Day: 30}, pdate{1404, Esfand, 29}},
+		{1403, EventRule{Month: Esfand, Day: -30}, pdate{1403, Esfand, 1}},
+		{1404, EventRule{Month: Esfand, Day: -29}, pdate{1404, Esfand, 1}},
+		{1404, EventRule{Month: Esfand, Day: -30}, pdate{1404, Esfand, 1}},
+		{1403, EventRule{Month: Farvardin, Day: 1, Offset: -1}, pdate{1402, Esfand, 29}},
+		{1403, EventRule{Month: Mehr, Day: 1, Offset: 100}, pdate{1403, Dey, 11}},
+	}
+
+	for _, v := range vals {
+		ti := ComputeEvent(v.year, v.rule, Iran())
+		if ti.Year() != v.expected.year || ti.Month() != v.expected.month || ti.Day() != v.expected.day || ti.Hour() != 0 {
+			t.Error(
+				"For", fmt.Sprintf("%d %+v", v.year, v.rule),
+				"expected", v.expected,
+				"got", ti.String(),
+			)
+		}
+	}
+
+	for _, rule := range []EventRule{{Month: 0, Day: 1}, {Month: Mehr, Day: 0}, {Month: Mehr, Day: 31}, {Month: Mehr, Day: -31}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error(
+						"For", fmt.Sprintf("%+v", rule),
+						"expected", "panic",
+						"got", nil,
+					)
+				}
+			}()
+			ComputeEvent(1403, rule, Iran())
+		}()
+	}
+}
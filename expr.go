@@ -0,0 +1,97 @@
+package ptime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AddExpr returns t shifted by the relative date expression expr, e.g. "+1y -2M +10d +3h".
+//
+// The expression is a list of terms separated by white spaces. Each term is an optional
+// sign (+ or -), a decimal number and one of the following units:
+//
+//	y    years
+//	M    months
+//	w    weeks
+//	d    days
+//	h    hours
+//	m    minutes
+//	s    seconds
+//
+// The years and months are added first and the day is clamped to the last day of the
+// resulting month (e.g. 1403/06/31 +1M is 1403/07/30). Then the weeks and days are added
+// to the date and finally the hours, minutes and seconds are added as a duration.
+// An empty expression returns t.
+func AddExpr(t Time, expr string) (Time, error) {
+	var months, days int
+	var d time.Duration
+
+	for _, term := range strings.Fields(expr) {
+		n, unit, err := parseExprTerm(term)
+		if err != nil {
+			return Time{}, fmt.Errorf("ptime: invalid expression %q: %v", expr, err)
+		}
+
+		switch unit {
+		case 'y':
+			months += 12 * n
+		case 'M':
+			months += n
+		case 'w':
+			days += 7 * n
+		case 'd':
+			days += n
+		case 'h':
+			d += time.Duration(n) * time.Hour
+		case 'm':
+			d += time.Duration(n) * time.Minute
+		case 's':
+			d += time.Duration(n) * time.Second
+		}
+	}
+
+	if months != 0 {
+		t = t.addMonthsClamp(months)
+	}
+	if days != 0 {
+		t = t.AddDate(0, 0, days)
+	}
+	if d != 0 {
+		t = t.Add(d)
+	}
+	return t, nil
+}
+
+// parseExprTerm parses a term of AddExpr and returns its signed number and unit.
+func parseExprTerm(term string) (int, byte, error) {
+	unit := term[len(term)-1]
+	if strings.IndexByte("yMwdhms", unit) < 0 {
+		return 0, 0, fmt.Errorf("unknown unit in %q", term)
+	}
+
+	num := term[:len(term)-1]
+	if num != "" && (num[0] == '+' || num[0] == '-') {
+		if len(num) == 1 || num[1] < '0' || num[1] > '9' {
+			return 0, 0, fmt.Errorf("missing number in %q", term)
+		}
+	}
+	n, err := strconv.Atoi(num)
+	if err != nil {
+		return 0, 0, fmt.Errorf("bad number in %q", term)
+	}
+	return n, unit, nil
+}
+
+// addMonthsClamp returns t shifted by months, clamping the day to the last day of the resulting month.
+func (t Time) addMonthsClamp(months int) Time {
+	m := int(t.month) - 1 + months
+	year, month := t.year+floorDiv(m, 12), Month(divider(m, 12)+1)
+
+	day := t.day
+	if n := monthLength(year, month); day > n {
+		day = n
+	}
+	return Date(year, month, day, t.hour, t.min, t.sec, t.nsec, t.loc)
+}
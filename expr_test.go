@@ -0,0 +1,61 @@
+package ptime_test
+
+import (
+	"testing"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+func TestAddExpr(t *testing.T) {
+	ti := Date(1403, Shahrivar, 31, 10, 20, 30, 0, Iran())
+
+	exprs := map[string]string{
+		"":                  "1403/06/31 10:20:30",
+		"+1y":               "1404/06/31 10:20:30",
+		"-1y":               "1402/06/31 10:20:30",
+		"+1M":               "1403/07/30 10:20:30",
+		"-2M":               "1403/04/31 10:20:30",
+		"6M":                "1403/12/30 10:20:30",
+		"+1y +6M":           "1404/12/29 10:20:30",
+		"-7M":               "1402/11/30 10:20:30",
+		"+2w":               "1403/07/14 10:20:30",
+		"+10d":              "1403/07/10 10:20:30",
+		"-31d":              "1403/05/31 10:20:30",
+		"+3h":               "1403/06/31 13:20:30",
+		"-11h":              "1403/06/30 23:20:30",
+		"+40m":              "1403/06/31 11:00:30",
+		"-30s":              "1403/06/31 10:20:00",
+		"+1y -2M +10d":      "1404/05/10 10:20:30",
+		"  +1M   +1d  -1h ": "1403/08/01 09:20:30",
+		"+1d +1d":           "1403/07/02 10:20:30",
+	}
+
+	for expr, expected := range exprs {
+		r, err := AddExpr(ti, expr)
+		if err != nil {
+			t.Error(
+				"For", expr,
+				"expected", expected,
+				"got", err,
+			)
+			continue
+		}
+		if s := r.Format("yyyy/MM/dd HH:mm:ss"); s != expected {
+			t.Error(
+				"For", expr,
+				"expected", expected,
+				"got", s,
+			)
+		}
+	}
+
+	for _, expr := range []string{"1", "+", "+d", "--1d", "+1x", "1 d", "+1.5d", "+1y+2M", "1D"} {
+		if _, err := AddExpr(ti, expr); err == nil {
+			t.Error(
+				"For", expr,
+				"expected", "error",
+				"got", nil,
+			)
+		}
+	}
+}
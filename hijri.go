@@ -0,0 +1,30 @@
+package ptime
+
+import "time"
+
+// FromHijriDate returns a new instance of Time corresponding to a day in the Hijri (lunar) calendar.
+// The time is set to 00:00:00 in the location loc.
+//
+// hy, hm and hd represent a day in Hijri calendar. The conversion uses the tabular
+// (arithmetic) Islamic calendar, whereas the official calendar is based on the sighting
+// of the moon, so the result may differ from the officially announced date by one or two days.
+//
+// loc is a pointer to time.Location and must not be nil.
+func FromHijriDate(hy, hm, hd int, loc *time.Location) Time {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to FromHijriDate")
+	}
+
+	year, month, day := getDate(hijriJdn(hy, hm, hd))
+	return Date(year, Month(month), day, 0, 0, 0, 0, loc)
+}
+
+// hijriJdn returns the Julian day number of a day in the tabular Islamic calendar.
+func hijriJdn(year, month, day int) int {
+	return day + floorDiv(59*(month-1)+1, 2) + (year-1)*354 + floorDiv(3+11*year, 30) + 1948439
+}
+
+// hijriYear returns the approximate year of the tabular Islamic calendar of the Julian day number jdn.
+func hijriYear(jdn int) int {
+	return floorDiv(30*(jdn-1948439)+10646, 10631)
+}
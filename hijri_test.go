@@ -0,0 +1,44 @@
+package ptime_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+type hijriConversion struct {
+	hy, hm, hd int
+	persian    pdate
+}
+
+var hijriConversions = []hijriConversion{
+	{1445, 9, 1, pdate{1402, Esfand, 21}},
+	{1445, 10, 1, pdate{1403, Farvardin, 22}},
+	{1444, 12, 10, pdate{1402, Tir, 8}},
+	{1446, 1, 1, pdate{1403, Tir, 18}},
+}
+
+func TestFromHijriDate(t *testing.T) {
+	for _, c := range hijriConversions {
+		ti := FromHijriDate(c.hy, c.hm, c.hd, Iran())
+		if ti.Year() != c.persian.year || ti.Month() != c.persian.month || ti.Day() != c.persian.day || ti.Hour() != 0 {
+			t.Error(
+				"For", fmt.Sprintf("%d/%d/%d", c.hy, c.hm, c.hd),
+				"expected", fmt.Sprintf("%d %s %d", c.persian.year, c.persian.month, c.persian.day),
+				"got", fmt.Sprintf("%d %s %d", ti.Year(), ti.Month(), ti.Day()),
+			)
+		}
+	}
+
+	// 1 Ramadan 1445 was officially announced on Esfand 22, 1402 in Iran.
+	official := Date(1402, Esfand, 22, 0, 0, 0, 0, Iran())
+	if ti := FromHijriDate(1445, 9, 1, Iran()); !ti.EqualApprox(official, 48*time.Hour) {
+		t.Error(
+			"For", "1445/9/1",
+			"expected", official.String(),
+			"got", ti.String(),
+		)
+	}
+}
@@ -0,0 +1,154 @@
+package ptime
+
+import (
+	"sort"
+	"sync"
+)
+
+// A Holiday specifies a yearly holiday in either Persian (solar) or Hijri (lunar) calendar.
+type Holiday struct {
+	// Name is the name of the holiday.
+	Name string
+	// Month is the month of the holiday in the range [1, 12] of its calendar.
+	Month int
+	// Day is the day of month of the holiday.
+	Day int
+	// Lunar reports whether Month and Day are in Hijri calendar.
+	// The lunar holidays are converted by the tabular Islamic calendar (see FromHijriDate).
+	Lunar bool
+}
+
+var (
+	holidaysMu sync.RWMutex
+	holidays   []Holiday
+)
+
+// IranHolidays returns the list of official holidays of Iran.
+func IranHolidays() []Holiday {
+	return []Holiday{
+		{Name: "عید نوروز", Month: 1, Day: 1},
+		{Name: "عید نوروز", Month: 1, Day: 2},
+		{Name: "عید نوروز", Month: 1, Day: 3},
+		{Name: "عید نوروز", Month: 1, Day: 4},
+		{Name: "روز جمهوری اسلامی", Month: 1, Day: 12},
+		{Name: "روز طبیعت", Month: 1, Day: 13},
+		{Name: "رحلت امام خمینی", Month: 3, Day: 14},
+		{Name: "قیام ۱۵ خرداد", Month: 3, Day: 15},
+		{Name: "پیروزی انقلاب اسلامی", Month: 11, Day: 22},
+		{Name: "ملی شدن صنعت نفت", Month: 12, Day: 29},
+		{Name: "تاسوعای حسینی", Month: 1, Day: 9, Lunar: true},
+		{Name: "عاشورای حسینی", Month: 1, Day: 10, Lunar: true},
+		{Name: "اربعین حسینی", Month: 2, Day: 20, Lunar: true},
+		{Name: "رحلت رسول اکرم و شهادت امام حسن مجتبی", Month: 2, Day: 28, Lunar: true},
+		{Name: "شهادت امام رضا", Month: 2, Day: 29, Lunar: true},
+		{Name: "شهادت امام حسن عسکری", Month: 3, Day: 8, Lunar: true},
+		{Name: "میلاد رسول اکرم و امام جعفر صادق", Month: 3, Day: 17, Lunar: true},
+		{Name: "شهادت حضرت فاطمه زهرا", Month: 6, Day: 3, Lunar: true},
+		{Name: "ولادت امام علی", Month: 7, Day: 13, Lunar: true},
+		{Name: "مبعث رسول اکرم", Month: 7, Day: 27, Lunar: true},
+		{Name: "ولادت حضرت قائم", Month: 8, Day: 15, Lunar: true},
+		{Name: "شهادت امام علی", Month: 9, Day: 21, Lunar: true},
+		{Name: "عید سعید فطر", Month: 10, Day: 1, Lunar: true},
+		{Name: "تعطیل به مناسبت عید سعید فطر", Month: 10, Day: 2, Lunar: true},
+		{Name: "شهادت امام جعفر صادق", Month: 10, Day: 25, Lunar: true},
+		{Name: "عید سعید قربان", Month: 12, Day: 10, Lunar: true},
+		{Name: "عید سعید غدیر خم", Month: 12, Day: 18, Lunar: true},
+	}
+}
+
+// RegisterHoliday registers the holidays hs. No holiday is registered by default,
+// e.g. RegisterHoliday(IranHolidays()...) registers the official holidays of Iran.
+//
+// The registered holidays are excluded from the business days.
+func RegisterHoliday(hs ...Holiday) {
+	holidaysMu.Lock()
+	defer holidaysMu.Unlock()
+	holidays = append(holidays, hs...)
+}
+
+// ClearHolidays removes all of the registered holidays.
+func ClearHolidays() {
+	holidaysMu.Lock()
+	defer holidaysMu.Unlock()
+	holidays = nil
+}
+
+// HolidaysInMonth returns the days of the month of the year which are registered holidays,
+// sorted by date. The time of each day is 00:00:00 in the default location (see DefaultLocation).
+//
+// The names of holidays of a day are returned by its HolidayNames method.
+func HolidaysInMonth(year int, month Month) []Time {
+	from := getJdn(year, int(month), 1)
+	to := from + monthLength(year, month) - 1
+
+	var hs []Time
+	loc := DefaultLocation()
+	for _, jdn := range holidayJdns(from, to) {
+		y, m, d := getDate(jdn)
+		hs = append(hs, Date(y, Month(m), d, 0, 0, 0, 0, loc))
+	}
+	return hs
+}
+
+// IsHoliday reports whether the day of t is a registered holiday.
+func (t Time) IsHoliday() bool {
+	return len(t.HolidayNames()) > 0
+}
+
+// HolidayNames returns the names of the registered holidays on the day of t.
+func (t Time) HolidayNames() []string {
+	jdn := t.jdn()
+
+	holidaysMu.RLock()
+	defer holidaysMu.RUnlock()
+
+	var names []string
+	for _, h := range holidays {
+		name := h.Name
+		h.occursIn(jdn, jdn, func(int) { names = append(names, name) })
+	}
+	return names
+}
+
+// holidayJdns returns the sorted Julian day numbers in the range [from, to] which are registered holidays.
+func holidayJdns(from, to int) []int {
+	holidaysMu.RLock()
+	defer holidaysMu.RUnlock()
+
+	seen := make(map[int]bool)
+	var jdns []int
+	for _, h := range holidays {
+		h.occursIn(from, to, func(jdn int) {
+			if !seen[jdn] {
+				seen[jdn] = true
+				jdns = append(jdns, jdn)
+			}
+		})
+	}
+
+	sort.Ints(jdns)
+	return jdns
+}
+
+// occursIn calls f for each Julian day number in the range [from, to] on which h occurs.
+func (h Holiday) occursIn(from, to int, f func(jdn int)) {
+	if h.Lunar {
+		for hy := hijriYear(from) - 1; hy <= hijriYear(to)+1; hy++ {
+			if jdn := hijriJdn(hy, h.Month, h.Day); jdn >= from && jdn <= to {
+				f(jdn)
+			}
+		}
+		return
+	}
+
+	fy, _, _ := getDate(from)
+	ty, _, _ := getDate(to)
+	for y := fy; y <= ty; y++ {
+		if h.Month < 1 || h.Month > 12 || h.Day < 1 || h.Day > monthLength(y, Month(h.Month)) {
+			continue
+		}
+		if jdn := getJdn(y, h.Month, h.Day); jdn >= from && jdn <= to {
+			f(jdn)
+		}
+	}
+}
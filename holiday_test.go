@@ -0,0 +1,86 @@
+package ptime_test
+
+import (
+	"testing"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+func TestHolidaysInMonth(t *testing.T) {
+	RegisterHoliday(IranHolidays()...)
+	defer ClearHolidays()
+
+	expected := []int{1, 2, 3, 4, 12, 13, 22, 23}
+	hs := HolidaysInMonth(1403, Farvardin)
+	if len(hs) != len(expected) {
+		t.Fatal(
+			"For", "1403 Farvardin",
+			"expected", len(expected), "holidays",
+			"got", len(hs),
+		)
+	}
+	for i, h := range hs {
+		if h.Year() != 1403 || h.Month() != Farvardin || h.Day() != expected[i] || !h.IsHoliday() {
+			t.Error(
+				"For", i,
+				"expected", expected[i],
+				"got", h.Format("yyyy/MM/dd"),
+			)
+		}
+	}
+
+	if names := hs[5].HolidayNames(); len(names) != 1 || names[0] != "روز طبیعت" {
+		t.Error(
+			"For", "Sizdah Bedar",
+			"expected", "روز طبیعت",
+			"got", names,
+		)
+	}
+	if names := hs[4].HolidayNames(); len(names) != 2 {
+		t.Error(
+			"For", "1403/01/12",
+			"expected", 2,
+			"got", names,
+		)
+	}
+	if ti := Date(1403, Farvardin, 5, 0, 0, 0, 0, Iran()); ti.IsHoliday() {
+		t.Error(
+			"For", "1403/01/05",
+			"expected", false,
+			"got", ti.HolidayNames(),
+		)
+	}
+}
+
+func TestHolidaysInMonthEmpty(t *testing.T) {
+	if hs := HolidaysInMonth(1403, Farvardin); len(hs) != 0 {
+		t.Error(
+			"For", "no registered holidays",
+			"expected", 0,
+			"got", len(hs),
+		)
+	}
+}
+
+func TestCountBusinessDaysHolidays(t *testing.T) {
+	RegisterHoliday(IranHolidays()...)
+	defer ClearHolidays()
+
+	// Farvardin 1403 has 5 Jomehs and 8 holidays of which Farvardin 3 is a Jomeh.
+	start := Date(1403, Farvardin, 1, 0, 0, 0, 0, Iran())
+	end := Date(1403, Farvardin, 31, 0, 0, 0, 0, Iran())
+	if n := CountBusinessDays(start, end); n != 19 {
+		t.Error(
+			"For", "1403 Farvardin",
+			"expected", 19,
+			"got", n,
+		)
+	}
+	if d := BusinessHoursBetween(start, end, WeekendConfig{}); d != 19 {
+		t.Error(
+			"For", "1403 Farvardin",
+			"expected", 19,
+			"got", d,
+		)
+	}
+}
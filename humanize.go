@@ -0,0 +1,121 @@
+package ptime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SplitDuration splits d into whole days, hours, minutes and seconds.
+// The fraction of a second is truncated.
+//
+// For a negative duration, all of the returned values are negative or zero.
+func SplitDuration(d time.Duration) (days, hours, mins, secs int) {
+	s := int64(d / time.Second)
+	days = int(s / 86400)
+	hours = int(s % 86400 / 3600)
+	mins = int(s % 3600 / 60)
+	secs = int(s % 60)
+	return days, hours, mins, secs
+}
+
+// HumanizeDurationFa returns the Persian representation of d in days, hours, minutes
+// and seconds with Persian digits, omitting zero units (e.g. ۲ روز و ۳ ساعت و ۱۵ دقیقه).
+// The fraction of a second is truncated.
+//
+// A negative duration is prefixed by منفی and a duration shorter than a second is ۰ ثانیه.
+func HumanizeDurationFa(d time.Duration) string {
+	days, hours, mins, secs := SplitDuration(d)
+
+	var prefix string
+	if d < 0 {
+		prefix = "منفی "
+		days, hours, mins, secs = -days, -hours, -mins, -secs
+	}
+
+	var parts []string
+	for _, u := range []struct {
+		n    int
+		unit string
+	}{
+		{days, "روز"},
+		{hours, "ساعت"},
+		{mins, "دقیقه"},
+		{secs, "ثانیه"},
+	} {
+		if u.n != 0 {
+			parts = append(parts, toPersianDigits(strconv.Itoa(u.n))+" "+u.unit)
+		}
+	}
+
+	if len(parts) == 0 {
+		return "۰ ثانیه"
+	}
+	return prefix + strings.Join(parts, " و ")
+}
+
+// humanDays maps the Persian names of the days near today to their distance from today.
+var humanDays = map[string]int{
+	"پریروز":  -2,
+	"دیروز":   -1,
+	"امروز":   0,
+	"فردا":    1,
+	"پس فردا": 2,
+}
+
+// humanUnits maps the Persian names of calendar units to their length in years, months and days.
+var humanUnits = map[string][3]int{
+	"روز":  {0, 0, 1},
+	"هفته": {0, 0, 7},
+	"ماه":  {0, 1, 0},
+	"سال":  {1, 0, 0},
+}
+
+// humanDirections maps the Persian words of relative phrases to the direction of time.
+var humanDirections = map[string]int{
+	"پیش":   -1,
+	"قبل":   -1,
+	"گذشته": -1,
+	"بعد":   1,
+	"دیگر":  1,
+	"آینده": 1,
+}
+
+// ParseHumanFa parses a Persian phrase of a date relative to now and returns the
+// corresponding time with the same clock as now. The supported phrases are:
+//
+//	امروز, دیروز, فردا, پریروز, پس فردا    today, yesterday, tomorrow, 2 days ago and 2 days later
+//	N UNIT DIRECTION                      e.g. ۳ روز پیش (3 days ago) or 2 ماه بعد (2 months later)
+//	UNIT DIRECTION                        e.g. هفته بعد (a week later) or سال گذشته (a year ago)
+//
+// where N is a non-negative number in Persian or Latin digits, UNIT is one of روز, هفته,
+// ماه and سال, and DIRECTION is one of پیش, قبل and گذشته for the past and بعد, دیگر and آینده
+// for the future. Adding months or years clamps the day to the last day of the resulting month.
+// Words may be separated by spaces or zero-width non-joiners.
+func ParseHumanFa(s string, now Time) (Time, error) {
+	fields := strings.Fields(strings.Replace(ToLatinDigits(s), "\u200c", " ", -1))
+	if days, ok := humanDays[strings.Join(fields, " ")]; ok {
+		return now.AddDate(0, 0, days), nil
+	}
+
+	n := 1
+	if len(fields) == 3 {
+		var err error
+		var rest string
+		if n, rest, err = parseNumber(fields[0], 1, 9, false); err != nil || rest != "" {
+			return Time{}, fmt.Errorf("ptime: invalid number %q in date phrase %q", fields[0], s)
+		}
+		fields = fields[1:]
+	}
+
+	if len(fields) == 2 {
+		unit, ok1 := humanUnits[fields[0]]
+		dir, ok2 := humanDirections[fields[1]]
+		if ok1 && ok2 {
+			n *= dir
+			return now.addMonthsClamp(n*(12*unit[0]+unit[1])).AddDate(0, 0, n*unit[2]), nil
+		}
+	}
+	return Time{}, fmt.Errorf("ptime: unrecognized date phrase %q", s)
+}
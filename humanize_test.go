@@ -0,0 +1,100 @@
+package ptime_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+type durationCase struct {
+	d                       time.Duration
+	days, hours, mins, secs int
+	fa                      string
+}
+
+var durationCases = []durationCase{
+	{0, 0, 0, 0, 0, "۰ ثانیه"},
+	{999 * time.Millisecond, 0, 0, 0, 0, "۰ ثانیه"},
+	{45 * time.Second, 0, 0, 0, 45, "۴۵ ثانیه"},
+	{59*time.Second + 999*time.Millisecond, 0, 0, 0, 59, "۵۹ ثانیه"},
+	{time.Minute, 0, 0, 1, 0, "۱ دقیقه"},
+	{24 * time.Hour, 1, 0, 0, 0, "۱ روز"},
+	{24*time.Hour - time.Second, 0, 23, 59, 59, "۲۳ ساعت و ۵۹ دقیقه و ۵۹ ثانیه"},
+	{51*time.Hour + 15*time.Minute, 2, 3, 15, 0, "۲ روز و ۳ ساعت و ۱۵ دقیقه"},
+	{-(51*time.Hour + 15*time.Minute), -2, -3, -15, 0, "منفی ۲ روز و ۳ ساعت و ۱۵ دقیقه"},
+	{-30 * time.Second, 0, 0, 0, -30, "منفی ۳۰ ثانیه"},
+}
+
+func TestSplitDuration(t *testing.T) {
+	for _, c := range durationCases {
+		days, hours, mins, secs := SplitDuration(c.d)
+		if days != c.days || hours != c.hours || mins != c.mins || secs != c.secs {
+			t.Error(
+				"For", c.d,
+				"expected", fmt.Sprint(c.days, c.hours, c.mins, c.secs),
+				"got", fmt.Sprint(days, hours, mins, secs),
+			)
+		}
+	}
+}
+
+func TestHumanizeDurationFa(t *testing.T) {
+	for _, c := range durationCases {
+		if s := HumanizeDurationFa(c.d); s != c.fa {
+			t.Error(
+				"For", c.d,
+				"expected", c.fa,
+				"got", s,
+			)
+		}
+	}
+}
+
+func TestParseHumanFa(t *testing.T) {
+	now := Date(1403, Esfand, 30, 14, 30, 0, 0, Iran())
+	vals := map[string]pdate{
+		"امروز":          {1403, Esfand, 30},
+		"دیروز":          {1403, Esfand, 29},
+		"فردا":           {1404, Farvardin, 1},
+		"پریروز":         {1403, Esfand, 28},
+		"پس فردا":        {1404, Farvardin, 2},
+		"پس\u200cفردا":   {1404, Farvardin, 2},
+		" امروز ":        {1403, Esfand, 30},
+		"۳ روز پیش":      {1403, Esfand, 27},
+		"3 روز قبل":      {1403, Esfand, 27},
+		"۱۰ روز دیگر":    {1404, Farvardin, 10},
+		"۰ روز بعد":      {1403, Esfand, 30},
+		"هفته بعد":       {1404, Farvardin, 7},
+		"هفته گذشته":     {1403, Esfand, 23},
+		"۲ هفته پیش":     {1403, Esfand, 16},
+		"ماه بعد":        {1404, Farvardin, 30},
+		"ماه آینده":      {1404, Farvardin, 30},
+		"۱۲ ماه قبل":     {1402, Esfand, 29},
+		"سال بعد":        {1404, Esfand, 29},
+		"۲ سال پیش":      {1401, Esfand, 29},
+		"سال\u200cگذشته": {1402, Esfand, 29},
+	}
+
+	for s, expected := range vals {
+		p, err := ParseHumanFa(s, now)
+		if err != nil || p.Year() != expected.year || p.Month() != expected.month || p.Day() != expected.day || p.Hour() != 14 || p.Minute() != 30 {
+			t.Error(
+				"For", s,
+				"expected", expected,
+				"got", p.String(), err,
+			)
+		}
+	}
+
+	for _, s := range []string{"", "دیروزها", "روز", "۳ روز", "سه روز پیش", "-3 روز پیش", "۳ دقیقه پیش", "۳ روز پیش دیگر"} {
+		if p, err := ParseHumanFa(s, now); err == nil {
+			t.Error(
+				"For", s,
+				"expected", "error",
+				"got", p.String(),
+			)
+		}
+	}
+}
@@ -0,0 +1,615 @@
+package ptime
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A ParseError describes a problem parsing a time string.
+type ParseError struct {
+	// Layout is the layout passed to Parse.
+	Layout string
+	// Value is the value passed to Parse.
+	Value string
+	// Token is the token of Layout which could not be parsed.
+	// It is empty if Value contains extra text at its end.
+	Token string
+	// Position is the byte offset in Value where the offending text starts.
+	Position int
+	// Err is the underlying error.
+	Err error
+}
+
+var (
+	errBadValue    = errors.New("bad value")
+	errOutOfRange  = errors.New("value out of range")
+	errUnsupported = errors.New("unsupported token")
+	errExtraText   = errors.New("extra text")
+)
+
+// Error returns the string representation of e.
+func (e *ParseError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("ptime: parsing %q as %q: %v: %q", e.Value, e.Layout, e.Err, e.Value[e.Position:])
+	}
+	return fmt.Sprintf("ptime: parsing %q as %q: cannot parse %q as %q: %v", e.Value, e.Layout, e.Value[e.Position:], e.Token, e.Err)
+}
+
+// Unwrap returns the underlying error of e.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// layoutTokens is the list of tokens supported by Format in the order of their precedence.
+var layoutTokens = []string{
+	"gyyyy", "gMMM", "gMM", "gdd",
+	"yyyy", "yyy", "yy", "y",
+	"MMMT", "MMM", "MMI", "MM", "M",
+	"rw", "w", "RW", "W", "RD", "DDD", "D", "rd",
+	"dd", "d",
+	"ET", "E", "e",
+	"A", "a", "P",
+	"HH", "H", "KK", "K", "kk", "k", "hh", "h",
+	"mm", "m",
+	"ns",
+	"ss", "s",
+	"SSS", "SS", "S", ".F",
+	"fffffffff", "ffffffff", "fffffff", "ffffff", "fffff", "ffff", "fff", "ff", "f",
+	"z", "Z",
+}
+
+// nextToken returns the token at the beginning of layout or an empty string if there is none.
+func nextToken(layout string) string {
+	for _, tok := range layoutTokens {
+		if strings.HasPrefix(layout, tok) {
+			return tok
+		}
+	}
+	return ""
+}
+
+// maxLayoutCache is the maximum number of layouts cached by compileLayout.
+const maxLayoutCache = 1024
+
+var (
+	layoutCache     sync.Map // map[string][]layoutChunk
+	layoutCacheSize int32
+)
+
+// A layoutChunk is either a token or a literal text of a layout.
+type layoutChunk struct {
+	token   string
+	literal string
+}
+
+// compileLayout splits layout into tokens and literal texts.
+//
+// The result is cached per layout, up to maxLayoutCache layouts, and must not be modified.
+func compileLayout(layout string) []layoutChunk {
+	if c, ok := layoutCache.Load(layout); ok {
+		return c.([]layoutChunk)
+	}
+
+	var chunks []layoutChunk
+	lit := 0
+	for i := 0; i < len(layout); {
+		tok := nextToken(layout[i:])
+		if tok == "" {
+			i++
+			continue
+		}
+		if lit < i {
+			chunks = append(chunks, layoutChunk{literal: layout[lit:i]})
+		}
+		chunks = append(chunks, layoutChunk{token: tok})
+		i += len(tok)
+		lit = i
+	}
+	if lit < len(layout) {
+		chunks = append(chunks, layoutChunk{literal: layout[lit:]})
+	}
+
+	if atomic.LoadInt32(&layoutCacheSize) < maxLayoutCache {
+		if _, loaded := layoutCache.LoadOrStore(layout, chunks); !loaded {
+			atomic.AddInt32(&layoutCacheSize, 1)
+		}
+	}
+	return chunks
+}
+
+// Parse parses a formatted string and returns the time value it represents.
+//
+// The layout is defined by the same tokens as Format. The tokens which are derived
+// from other fields (rw, RW, W, RD, rd, gyyyy, gMMM, gMM, gdd) and yy are not supported.
+// A year followed by a number without a separator (e.g. yyyyMMdd) must have at most 4 digits.
+//
+// The date may be given by the day of year (D or DDD) or by the week of year (w) and
+// the name of weekday (E or e) instead of the month and day. If the weekday is absent,
+// the first day of the week in the year is used. Otherwise, the names of weekday are
+// accepted but ignored.
+//
+// The Persian and Arabic-Indic digits of value are accepted as well as the ASCII digits
+// (see ToLatinDigits).
+//
+// In the absence of a location (z) or a zone offset (Z), Parse returns a time in
+// the default location (see DefaultLocation). If the parsing fails, the returned error is a *ParseError.
+func Parse(layout, value string) (Time, error) {
+	return ParseInLocation(layout, value, DefaultLocation())
+}
+
+// ParseInLocation is like Parse but interprets the time in the location loc
+// in the absence of a location (z) in the value.
+//
+// If the value contains a zone offset (Z) which is not the offset of loc at that time,
+// the returned time is in a fixed zone with the given offset.
+//
+// loc is a pointer to time.Location and must not be nil.
+func ParseInLocation(layout, value string, loc *time.Location) (Time, error) {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to ParseInLocation")
+	}
+
+	var (
+		year, month, day          = 0, 1, 1
+		hour, min, sec, nsec      int
+		pm, hasPm                 bool
+		offset                    int
+		hasOffset                 bool
+		monthTok, dayTok, hourTok string
+		monthPos, dayPos, hourPos int
+		yearDay, week, wday       int
+		hasWday                   bool
+		dateTok                   string
+		datePos                   int
+	)
+
+	orig := value
+	value = ToLatinDigits(value)
+	perr := func(tok string, pos int, err error) (Time, error) {
+		return Time{}, &ParseError{Layout: layout, Value: orig, Token: tok, Position: latinOffset(orig, pos), Err: err}
+	}
+
+	v := value
+	for l := layout; l != ""; {
+		pos := len(value) - len(v)
+		tok := nextToken(l)
+		if tok == "" {
+			r, size := utf8.DecodeRuneInString(l)
+			if !strings.HasPrefix(v, l[:size]) {
+				return perr(string(r), pos, errBadValue)
+			}
+			l, v = l[size:], v[size:]
+			continue
+		}
+		l = l[len(tok):]
+
+		var (
+			n   int
+			err error
+		)
+		switch tok {
+		case "yyyy", "yyy", "y":
+			// The year is limited to 4 digits if a number follows it without a separator (e.g. yyyyMMdd).
+			max := 9
+			if isNumberToken(nextToken(l)) {
+				max = 4
+			}
+			n, v, err = parseNumber(v, 1, max, true)
+			year = n
+		case "MMM":
+			n, v, err = parseLocalName(v, months[:])
+			month, monthTok, monthPos = n+1, tok, pos
+		case "MMMT":
+			n, v, err = parseLocalName(v, lmonths[:])
+			month, monthTok, monthPos = n+1, tok, pos
+		case "MMI":
+			n, v, err = parseLocalName(v, dmonths[:])
+			month, monthTok, monthPos = n+1, tok, pos
+		case "MM", "M":
+			n, v, err = parseNumber(v, len(tok), 2, false)
+			month, monthTok, monthPos = n, tok, pos
+		case "dd", "d":
+			n, v, err = parseNumber(v, len(tok), 2, false)
+			day, dayTok, dayPos = n, tok, pos
+		case "DDD", "D":
+			n, v, err = parseNumber(v, len(tok), 3, false)
+			yearDay, dateTok, datePos = n, tok, pos
+		case "w":
+			n, v, err = parseNumber(v, 1, 2, false)
+			week, dateTok, datePos = n, tok, pos
+		case "E":
+			wday, v, err = parseLocalName(v, days[:])
+			hasWday = true
+		case "e":
+			wday, v, err = parseLocalName(v, sdays[:])
+			hasWday = true
+		case "ET":
+			wday, v, err = parseLocalName(v, ldays[:])
+			hasWday = true
+		case "A", "a", "P":
+			full, short := amPmNames()
+			names := full[:]
+			if tok == "a" {
+				names = short[:]
+			} else if tok == "P" {
+				names = enAmPm[:]
+			}
+			n, v, err = parseName(v, names)
+			pm, hasPm = n == int(Pm), true
+		case "HH", "H", "KK", "K", "kk", "k", "hh", "h":
+			n, v, err = parseNumber(v, len(tok), 2, false)
+			hour, hourTok, hourPos = n, tok, pos
+		case "mm", "m":
+			n, v, err = parseNumber(v, len(tok), 2, false)
+			min = n
+			if err == nil && n > 59 {
+				err = errOutOfRange
+			}
+		case "ss", "s":
+			n, v, err = parseNumber(v, len(tok), 2, false)
+			sec = n
+			if err == nil && n > 59 {
+				err = errOutOfRange
+			}
+		case "ns":
+			n, v, err = parseNumber(v, 1, 9, false)
+			nsec = n
+		case "SSS", "SS", "S":
+			digits := 3 * len(tok)
+			n, v, err = parseNumber(v, digits, digits, false)
+			nsec = n * pow10(9-digits)
+		case ".F":
+			if strings.HasPrefix(v, ".") {
+				rest := v[1:]
+				n, v, err = parseNumber(rest, 1, 9, false)
+				nsec = n * pow10(9-(len(rest)-len(v)))
+			}
+		case "fffffffff", "ffffffff", "fffffff", "ffffff", "fffff", "ffff", "fff", "ff", "f":
+			n, v, err = parseNumber(v, len(tok), len(tok), false)
+			nsec = n * pow10(9-len(tok))
+		case "z":
+			i := strings.IndexAny(v, " \t")
+			if i < 0 {
+				i = len(v)
+			}
+			var zl *time.Location
+			zl, err = time.LoadLocation(v[:i])
+			if err == nil {
+				loc, v = zl, v[i:]
+			}
+		case "Z":
+			offset, v, err = parseOffset(v)
+			hasOffset = true
+		default:
+			err = errUnsupported
+		}
+
+		if err != nil {
+			return perr(tok, pos, err)
+		}
+	}
+
+	if v != "" {
+		return perr("", len(value)-len(v), errExtraText)
+	}
+
+	if hourTok != "" {
+		var ok bool
+		if hour, ok = hour24(hour, hourTok[0], hasPm && pm); !ok {
+			return perr(hourTok, hourPos, errOutOfRange)
+		}
+	}
+
+	if dateTok == "w" {
+		first := jdnWeekday(nowruzJdn(year))
+		if !hasWday {
+			wday = int(Shanbeh)
+			if week == 1 {
+				wday = int(first)
+			}
+		}
+		yearDay = 7*(week-1) + wday - int(first) + 1
+	}
+
+	if dateTok != "" {
+		if yearDay < 1 || yearDay > YearLength(year) {
+			return perr(dateTok, datePos, errOutOfRange)
+		}
+
+		y, m, d := getDate(nowruzJdn(year) + yearDay - 1)
+		if (monthTok != "" && m != month) || (dayTok != "" && d != day) {
+			return perr(dateTok, datePos, errBadValue)
+		}
+		year, month, day = y, m, d
+	}
+
+	if month < 1 || month > 12 {
+		return perr(monthTok, monthPos, errOutOfRange)
+	}
+
+	if day < 1 || day > monthLength(year, Month(month)) {
+		return perr(dayTok, dayPos, errOutOfRange)
+	}
+
+	t := Date(year, Month(month), day, hour, min, sec, nsec, loc)
+	if hasOffset {
+		if _, off := t.Zone(); off != offset {
+			t = Date(year, Month(month), day, hour, min, sec, nsec, time.FixedZone("", offset))
+		}
+	}
+
+	return t, nil
+}
+
+// ParseSlash parses a date in the format of yyyy/MM/dd and returns the time
+// at 00:00:00 of that day in the location loc.
+//
+// The month and day may be written with one or two digits and the
+// components may also be separated by '-' (e.g. 1403/1/5, 1403/01/05 or 1403-01-05).
+//
+// loc is a pointer to time.Location and must not be nil.
+func ParseSlash(s string, loc *time.Location) (Time, error) {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to ParseSlash")
+	}
+
+	return ParseInLocation("y/M/d", strings.Replace(s, "-", "/", 2), loc)
+}
+
+// rfc3339Layout is the layout of RFC3339 and String without the fraction of second.
+const rfc3339Layout = "yyyy-MM-ddTHH:mm:ssZ"
+
+// ParseRFC3339 parses a time in the format of RFC 3339 with an optional fraction
+// of second (e.g. 1394-07-02T12:59:59.05026+03:30), as returned by RFC3339 and String.
+//
+// A time in UTC (Z) is returned in UTC and any other offset is returned in the default
+// location (see DefaultLocation) if it is the offset of that location at that time,
+// or in a fixed zone otherwise.
+func ParseRFC3339(s string) (Time, error) {
+	loc := DefaultLocation()
+	if strings.HasSuffix(s, "Z") {
+		loc = time.UTC
+	}
+
+	v, nsec, start, end := s, 0, 0, 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		j := i + 1
+		for j < len(s) && j-i-1 < 9 && s[j] >= '0' && s[j] <= '9' {
+			j++
+		}
+		if j == i+1 {
+			return Time{}, &ParseError{Layout: rfc3339Layout, Value: s, Token: "f", Position: i, Err: errBadValue}
+		}
+
+		n, _ := strconv.Atoi(s[i+1 : j])
+		v, nsec, start, end = s[:i]+s[j:], n*pow10(9-(j-i-1)), i, j
+	}
+
+	t, err := ParseInLocation(rfc3339Layout, v, loc)
+	if err != nil {
+		if perr, ok := err.(*ParseError); ok {
+			perr.Value = s
+			if end > 0 && perr.Position >= start {
+				perr.Position += end - start
+			}
+		}
+		return Time{}, err
+	}
+
+	return t.WithNanosecond(nsec), nil
+}
+
+// ParseGregorian parses a Gregorian time by time.ParseInLocation and returns
+// the corresponding Persian time, e.g. ParseGregorian(time.RFC3339, "2015-09-24T12:59:59+03:30", Iran()).
+//
+// loc is a pointer to time.Location and must not be nil.
+func ParseGregorian(layout, value string, loc *time.Location) (Time, error) {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to ParseGregorian")
+	}
+
+	g, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return Time{}, err
+	}
+	return New(g), nil
+}
+
+// ParseMonth returns the month whose Persian (e.g. فروردین), Dari (e.g. حمل) or Latin
+// (e.g. Farvardin) name is s. The Persian and Dari names are compared after NormalizePersian
+// and regardless of the spaces and the zero-width non-joiners, and the Latin names are
+// case-insensitive.
+func ParseMonth(s string) (Month, error) {
+	key := nameKey(s)
+	for i := range months {
+		if key == nameKey(months[i]) || key == nameKey(dmonths[i]) || strings.EqualFold(key, lmonths[i]) {
+			return Month(i + 1), nil
+		}
+	}
+	return 0, fmt.Errorf("ptime: unknown month name %q", s)
+}
+
+// ParseWeekday returns the weekday whose Persian (e.g. یک‌شنبه), Persian short (e.g. ی)
+// or Latin (e.g. Yekshanbeh) name is s. The names are compared as in ParseMonth,
+// so e.g. یکشنبه and يک‌شنبه (with an Arabic yeh) are accepted as well.
+func ParseWeekday(s string) (Weekday, error) {
+	key := nameKey(s)
+	for i := range days {
+		if key == nameKey(days[i]) || key == sdays[i] || strings.EqualFold(key, ldays[i]) {
+			return Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("ptime: unknown weekday name %q", s)
+}
+
+// nameKey returns s normalized by NormalizePersian without spaces and zero-width non-joiners.
+func nameKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\u200c' || unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, NormalizePersian(s))
+}
+
+// hour24 converts hour of the token kind (H, K, k or h) to the range [0, 23].
+func hour24(hour int, kind byte, pm bool) (int, bool) {
+	switch kind {
+	case 'H':
+		return hour, hour <= 23
+	case 'k':
+		return hour % 24, hour >= 1 && hour <= 24
+	case 'K':
+		if hour > 11 {
+			return hour, false
+		}
+		if pm {
+			hour += 12
+		}
+		return hour, true
+	}
+
+	if hour < 1 || hour > 12 {
+		return hour, false
+	}
+	hour %= 12
+	if pm {
+		hour += 12
+	}
+	return hour, true
+}
+
+// isNumberToken reports whether tok is parsed as a number by ParseInLocation.
+func isNumberToken(tok string) bool {
+	switch tok {
+	case "yyyy", "yyy", "y", "MM", "M", "dd", "d", "DDD", "D", "w",
+		"HH", "H", "KK", "K", "kk", "k", "hh", "h", "mm", "m", "ss", "s", "ns", "SSS", "SS", "S",
+		"fffffffff", "ffffffff", "fffffff", "ffffff", "fffff", "ffff", "fff", "ff", "f":
+		return true
+	}
+	return false
+}
+
+// parseNumber parses a decimal number of at least min and at most max digits at the beginning of v.
+func parseNumber(v string, min, max int, signed bool) (int, string, error) {
+	i := 0
+	if signed && i < len(v) && (v[i] == '+' || v[i] == '-') {
+		i++
+	}
+
+	start := i
+	for i < len(v) && i-start < max && v[i] >= '0' && v[i] <= '9' {
+		i++
+	}
+
+	if i-start < min {
+		return 0, v, errBadValue
+	}
+
+	n, err := strconv.Atoi(v[:i])
+	if err != nil {
+		return 0, v, err
+	}
+	return n, v[i:], nil
+}
+
+// latinOffset returns the byte offset in s which corresponds to the byte offset i
+// in ToLatinDigits(s).
+func latinOffset(s string, i int) int {
+	n := 0
+	for j, r := range s {
+		if n >= i {
+			return j
+		}
+		if (r >= '۰' && r <= '۹') || (r >= '٠' && r <= '٩') {
+			n++
+		} else {
+			n += utf8.RuneLen(r)
+		}
+	}
+	return len(s)
+}
+
+// parseName returns the index of the longest name which v starts with.
+func parseName(v string, names []string) (int, string, error) {
+	idx := -1
+	for i, name := range names {
+		if strings.HasPrefix(v, name) && (idx < 0 || len(name) > len(names[idx])) {
+			idx = i
+		}
+	}
+
+	if idx < 0 {
+		return 0, v, errBadValue
+	}
+	return idx, v[len(names[idx]):], nil
+}
+
+// parseLocalName is like parseName but treats the Arabic kaf and yeh in v
+// and in names as the Persian ones.
+func parseLocalName(v string, names []string) (int, string, error) {
+	norm := make([]string, len(names))
+	for i, name := range names {
+		norm[i] = NormalizePersian(name)
+	}
+
+	// persianReplacer keeps the byte length of v, so rest is a suffix of v too.
+	n, rest, err := parseName(persianReplacer.Replace(v), norm)
+	if err != nil {
+		return 0, v, err
+	}
+	return n, v[len(v)-len(rest):], nil
+}
+
+// parseOffset parses a zone offset in the format of Z or [+|-]HH[[:]mm].
+func parseOffset(v string) (int, string, error) {
+	if strings.HasPrefix(v, "Z") {
+		return 0, v[1:], nil
+	}
+
+	if v == "" || (v[0] != '+' && v[0] != '-') {
+		return 0, v, errBadValue
+	}
+
+	sign := 1
+	if v[0] == '-' {
+		sign = -1
+	}
+
+	h, rest, err := parseNumber(v[1:], 2, 2, false)
+	if err != nil {
+		return 0, v, err
+	}
+
+	var m int
+	if strings.HasPrefix(rest, ":") {
+		rest = rest[1:]
+		if m, rest, err = parseNumber(rest, 2, 2, false); err != nil {
+			return 0, v, err
+		}
+	} else if len(rest) >= 2 && rest[0] >= '0' && rest[0] <= '9' {
+		if m, rest, err = parseNumber(rest, 2, 2, false); err != nil {
+			return 0, v, err
+		}
+	}
+
+	if h > 23 || m > 59 {
+		return 0, v, errOutOfRange
+	}
+	return sign * (h*3600 + m*60), rest, nil
+}
+
+func pow10(n int) int {
+	p := 1
+	for ; n > 0; n-- {
+		p *= 10
+	}
+	return p
+}
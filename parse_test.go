@@ -0,0 +1,551 @@
+package ptime_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+type parseCase struct {
+	layout string
+	value  string
+	result string
+}
+
+var parseCases = []parseCase{
+	{"yyyy/MM/dd", "1394/07/02", "1394-07-02T00:00:00.000000000+03:30"},
+	{"y/M/d", "1394/9/5", "1394-09-05T00:00:00.000000000+03:30"},
+	{"d MMM yyyy", "2 مهر 1394", "1394-07-02T00:00:00.000000000+03:30"},
+	{"d MMI yyyy", "2 میزان 1394", "1394-07-02T00:00:00.000000000+03:30"},
+	{"E d MMM yyyy HH:mm:ss", "پنج‌شنبه 2 مهر 1394 14:07:08", "1394-07-02T14:07:08.000000000+03:30"},
+	{"yyyy/MM/dd hh:mm:ss a", "1394/11/11 09:54:30 ب.ظ", "1394-11-11T21:54:30.000000000+03:30"},
+	{"yyyy/MM/dd h:mm A", "1394/11/11 12:05 قبل از ظهر", "1394-11-11T00:05:00.000000000+03:30"},
+	{"yyyy/MM/dd KK:mm P", "1394/11/11 11:05 PM", "1394-11-11T23:05:00.000000000+03:30"},
+	{"yyyy/MM/dd kk:mm", "1394/11/11 24:05", "1394-11-11T00:05:00.000000000+03:30"},
+	{"yyyy/MM/dd HH:mm:ss.S", "1394/11/11 10:00:00.050", "1394-11-11T10:00:00.050000000+03:30"},
+	{"yyyy/MM/dd HH:mm:ss.SS", "1394/11/11 10:00:00.050260", "1394-11-11T10:00:00.050260000+03:30"},
+	{"yyyy/MM/dd HH:mm:ss.SSS", "1394/11/11 10:00:00.050260050", "1394-11-11T10:00:00.050260050+03:30"},
+	{"yyyy/MM/dd HH:mm:ss.ffff", "1394/11/11 10:00:00.0502", "1394-11-11T10:00:00.050200000+03:30"},
+	{"yyyy/MM/dd HH:mm:ss.ns", "1394/11/11 10:00:00.50260050", "1394-11-11T10:00:00.050260050+03:30"},
+	{"yyyy/MM/dd HH:mm z", "1394/11/11 10:00 Asia/Kabul", "1394-11-11T10:00:00.000000000+04:30"},
+	{"yyyy/MM/dd HH:mmZ", "1394/11/11 10:00+03:30", "1394-11-11T10:00:00.000000000+03:30"},
+	{"yyyy/MM/dd HH:mmZ", "1394/11/11 10:00Z", "1394-11-11T10:00:00.000000000+00:00"},
+	{"yyyy/MM/dd HH:mmZ", "1394/11/11 10:00-0200", "1394-11-11T10:00:00.000000000-02:00"},
+	{"yyyy/MM/dd", "1395/12/30", "1395-12-30T00:00:00.000000000+03:30"},
+}
+
+func TestParse(t *testing.T) {
+	for _, p := range parseCases {
+		ti, err := Parse(p.layout, p.value)
+		if err != nil {
+			t.Error(
+				"For", p.value,
+				"expected", p.result,
+				"got", err,
+			)
+			continue
+		}
+
+		if s := ti.Format("yyyy-MM-ddTHH:mm:ss.SSSZ"); s != p.result {
+			t.Error(
+				"For", p.value,
+				"expected", p.result,
+				"got", s,
+			)
+		}
+	}
+}
+
+func TestParseInLocation(t *testing.T) {
+	ti, err := ParseInLocation("yyyy/MM/dd HH:mm", "1394/07/02 14:07", Afghanistan())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ti.Location().String() != "Asia/Kabul" || ti.Hour() != 14 || ti.Minute() != 7 {
+		t.Error(
+			"For", "ParseInLocation()",
+			"expected", "1394/07/02 14:07 Asia/Kabul",
+			"got", ti.Format("yyyy/MM/dd HH:mm z"),
+		)
+	}
+}
+
+type parseErrorCase struct {
+	layout   string
+	value    string
+	token    string
+	position int
+}
+
+var parseErrorCases = []parseErrorCase{
+	{"yyyy/MM/dd", "1394/1x/02", "MM", 5},
+	{"yyyy/MM/dd", "1394-07-02", "/", 4},
+	{"yyyy/MM/dd", "1394/13/02", "MM", 5},
+	{"yyyy/MM/dd", "1394/12/30", "dd", 8},
+	{"yyyy/MM/dd", "1394/07/02 10:00", "", 10},
+	{"d MMM yyyy", "2 مهره 1394", " ", 8},
+	{"d MMM yyyy", "2 مرد 1394", "MMM", 2},
+	{"yyyy/MM/dd HH:mm", "1394/07/02 24:00", "HH", 11},
+	{"yyyy/MM/dd HH:mm", "1394/07/02 10:60", "mm", 14},
+	{"yyyy/MM/dd hh:mm", "1394/07/02 00:10", "hh", 11},
+	{"yyyy/MM/dd HH:mmZ", "1394/07/02 10:00+3:30", "Z", 16},
+	{"yyyy/MM/dd z", "1394/07/02 Asia/Nowhere", "z", 11},
+	{"yyyy/RD", "1394/178", "RD", 5},
+}
+
+func TestParseError(t *testing.T) {
+	for _, p := range parseErrorCases {
+		_, err := Parse(p.layout, p.value)
+
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Error(
+				"For", p.value,
+				"expected", "*ParseError",
+				"got", err,
+			)
+			continue
+		}
+
+		if perr.Token != p.token || perr.Position != p.position || perr.Layout != p.layout || perr.Value != p.value {
+			t.Error(
+				"For", p.value,
+				"expected", fmt.Sprintf("%q at %d", p.token, p.position),
+				"got", fmt.Sprintf("%q at %d", perr.Token, perr.Position),
+			)
+		}
+
+		if perr.Unwrap() == nil || !strings.HasPrefix(perr.Error(), "ptime: parsing ") {
+			t.Error(
+				"For", p.value,
+				"expected", "a descriptive error",
+				"got", perr.Error(),
+			)
+		}
+	}
+}
+
+func TestParseFormatRoundTrip(t *testing.T) {
+	layout := "yyyy/MM/dd E HH:mm:ss.SSS z"
+	for _, loc := range []*time.Location{Iran(), Afghanistan(), time.UTC} {
+		ti := Date(1394, Mehr, 2, 14, 7, 8, 50260050, loc)
+		p, err := Parse(layout, ti.Format(layout))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p.UnixNano() != ti.UnixNano() || p.Location().String() != loc.String() {
+			t.Error(
+				"For", ti.Format(layout),
+				"expected", ti.String(),
+				"got", p.String(),
+			)
+		}
+	}
+}
+
+func TestParseDayKey(t *testing.T) {
+	for _, p := range []pdate{{1394, Mehr, 2}, {1403, Esfand, 30}, {800, Farvardin, 1}, {9, Dey, 5}} {
+		ti := Date(p.year, p.month, p.day, 0, 0, 0, 0, Iran())
+		if u, err := Parse("yyyyMMdd", ti.DayKey()); err != nil || u.UnixNano() != ti.UnixNano() {
+			t.Error(
+				"For", ti.DayKey(),
+				"expected", ti.String(),
+				"got", u.String(), err,
+			)
+		}
+
+		if u, err := Parse("yyyyMM", ti.MonthKey()); err != nil || u.UnixNano() != ti.AddDate(0, 0, 1-p.day).UnixNano() {
+			t.Error(
+				"For", ti.MonthKey(),
+				"expected", ti.AddDate(0, 0, 1-p.day).String(),
+				"got", u.String(), err,
+			)
+		}
+	}
+
+	// Format does not pad the year, so only 4-digit years are formatted without separators.
+	ti := Date(1394, Mehr, 2, 14, 7, 8, 0, Iran())
+	if u, err := Parse("yyyyMMddHHmmss", ti.Format("yyyyMMddHHmmss")); err != nil || u.UnixNano() != ti.UnixNano() {
+		t.Error(
+			"For", ti.Format("yyyyMMddHHmmss"),
+			"expected", ti.String(),
+			"got", u.String(), err,
+		)
+	}
+
+	// Without a following number, the year is not limited to 4 digits.
+	if u, err := Parse("yyyy/MM/dd", "12345/01/01"); err != nil || u.Year() != 12345 {
+		t.Error(
+			"For", "12345/01/01",
+			"expected", 12345,
+			"got", u.Year(), err,
+		)
+	}
+}
+
+func TestParseShortFraction(t *testing.T) {
+	layout := "yyyy/MM/dd HH:mm:ss.F"
+	for _, nsec := range []int{0, 1, 1000000, 123456789} {
+		ti := Date(1394, Mehr, 2, 14, 7, 8, nsec, Iran())
+		p, err := Parse(layout, ti.Format(layout))
+		if err != nil || p.Nanosecond() != nsec {
+			t.Error(
+				"For", ti.Format(layout),
+				"expected", nsec,
+				"got", p.Nanosecond(), err,
+			)
+		}
+	}
+
+	if p, err := Parse(layout, "1394/07/02 14:07:08."); err == nil {
+		t.Error(
+			"For", "1394/07/02 14:07:08.",
+			"expected", "error",
+			"got", p.String(),
+		)
+	}
+}
+
+func TestParseSlash(t *testing.T) {
+	for _, s := range []string{"1403/1/5", "1403/01/05", "1403-01-05", "1403-1-5", "1403/01-5", "۱۴۰۳/۰۱/۰۵", "۱۴۰۳-۱-5", "١٤٠٣/١/٥"} {
+		ti, err := ParseSlash(s, Iran())
+		if err != nil {
+			t.Error(
+				"For", s,
+				"expected", "1403/01/05",
+				"got", err,
+			)
+			continue
+		}
+
+		if ti.Format("yyyy/MM/dd HH:mm:ss z") != "1403/01/05 00:00:00 Asia/Tehran" {
+			t.Error(
+				"For", s,
+				"expected", "1403/01/05 00:00:00 Asia/Tehran",
+				"got", ti.Format("yyyy/MM/dd HH:mm:ss z"),
+			)
+		}
+	}
+
+	for _, s := range []string{"1403/13/05", "1403/01/32", "1404/12/30", "1403/01", "1403.01.05", "1403/01/05/"} {
+		if _, err := ParseSlash(s, Iran()); err == nil {
+			t.Error(
+				"For", s,
+				"expected", "error",
+				"got", nil,
+			)
+		}
+	}
+}
+
+func TestParsePersianDigits(t *testing.T) {
+	ti, err := Parse("yyyy/MM/dd HH:mm:ss", "۱۳۹۴/۰۷/۰۲ ۱۴:۰۵:۵۹")
+	if err != nil {
+		t.Fatal(
+			"For", "۱۳۹۴/۰۷/۰۲ ۱۴:۰۵:۵۹",
+			"expected", "nil",
+			"got", err,
+		)
+	}
+	if s := ti.Format("yyyy/MM/dd HH:mm:ss"); s != "1394/07/02 14:05:59" {
+		t.Error(
+			"For", "۱۳۹۴/۰۷/۰۲ ۱۴:۰۵:۵۹",
+			"expected", "1394/07/02 14:05:59",
+			"got", s,
+		)
+	}
+
+	if s := ToLatinDigits("۱۴۰۳/٠١/15 مهر"); s != "1403/01/15 مهر" {
+		t.Error(
+			"For", "۱۴۰۳/٠١/15 مهر",
+			"expected", "1403/01/15 مهر",
+			"got", s,
+		)
+	}
+}
+
+func TestParsePersianDigitsError(t *testing.T) {
+	vals := map[string]string{
+		"۱۴۰۳/۱۳/۰۱":    "۱۳/۰۱",
+		"١٤٠٣/01/x1":    "x1",
+		"۱۴۰۳/۰۱/۰۱ ۱۲": " ۱۲",
+	}
+
+	for v, rest := range vals {
+		_, err := Parse("yyyy/MM/dd", v)
+		perr, ok := err.(*ParseError)
+		if !ok || perr.Value != v || perr.Value[perr.Position:] != rest {
+			t.Error(
+				"For", v,
+				"expected", rest,
+				"got", err,
+			)
+		}
+	}
+}
+
+func TestParseArabicLetters(t *testing.T) {
+	vals := map[string][2]string{
+		"1403 \u062f\u064a 01":                                  {"yyyy MMM dd", "1403/10/01"},
+		"\u062f\u0649 1403/10/01":                               {"MMM yyyy/MM/dd", "1403/10/01"},
+		"\u064a\u0643\u200c\u0634\u0646\u0628\u0647 1403/10/02": {"E yyyy/MM/dd", "1403/10/02"},
+		"1403/10/02 \u064a":                                     {"yyyy/MM/dd e", "1403/10/02"},
+	}
+
+	for v, f := range vals {
+		ti, err := Parse(f[0], v)
+		if err != nil {
+			t.Error(
+				"For", fmt.Sprintf("%q", v),
+				"expected", f[1],
+				"got", err,
+			)
+			continue
+		}
+		if s := ti.Format("yyyy/MM/dd"); s != f[1] {
+			t.Error(
+				"For", fmt.Sprintf("%q", v),
+				"expected", f[1],
+				"got", s,
+			)
+		}
+	}
+}
+
+func TestParseOrdinalDate(t *testing.T) {
+	for _, year := range []int{1403, 1404} {
+		for ti := Date(year, Farvardin, 1, 0, 0, 0, 0, Iran()); ti.Year() == year; ti = ti.AddDate(0, 0, 1) {
+			p, err := Parse("yyyy-DDD", ti.OrdinalDate())
+			if err != nil || p.Year() != ti.Year() || p.Month() != ti.Month() || p.Day() != ti.Day() {
+				t.Error(
+					"For", ti.OrdinalDate(),
+					"expected", ti.Format("yyyy/MM/dd"),
+					"got", p.Format("yyyy/MM/dd"), err,
+				)
+			}
+
+			layout := "yyyy w E"
+			p, err = Parse(layout, ti.Format(layout))
+			if err != nil || p.Year() != ti.Year() || p.Month() != ti.Month() || p.Day() != ti.Day() {
+				t.Error(
+					"For", ti.Format(layout),
+					"expected", ti.Format("yyyy/MM/dd"),
+					"got", p.Format("yyyy/MM/dd"), err,
+				)
+			}
+		}
+	}
+
+	if s := Date(1403, Esfand, 30, 0, 0, 0, 0, Iran()).OrdinalDate(); s != "1403-366" {
+		t.Error(
+			"For", "1403/12/30",
+			"expected", "1403-366",
+			"got", s,
+		)
+	}
+
+	vals := []struct {
+		layout, value, expected string
+	}{
+		{"yyyy D", "1394 188", "1394/07/02"},
+		{"yyyy/MM D", "1394/07 188", "1394/07/02"},
+		{"yyyy w", "1394 27", "1394/06/28"},
+		{"yyyy w", "1394 1", "1394/01/01"},
+		{"yyyy w e", "1394 27 پ", "1394/07/02"},
+		{"yyyy w E", "1403 2 سه‌شنبه", "1403/01/07"},
+	}
+	for _, v := range vals {
+		p, err := Parse(v.layout, v.value)
+		if err != nil || p.Format("yyyy/MM/dd") != v.expected {
+			t.Error(
+				"For", v.value,
+				"expected", v.expected,
+				"got", p.Format("yyyy/MM/dd"), err,
+			)
+		}
+	}
+
+	for layout, value := range map[string]string{
+		"yyyy-DDD":  "1404-366",
+		"yyyy-D":    "1403-0",
+		"yyyy/MM D": "1394/08 188",
+		"yyyy w":    "1403 55",
+		"yyyy w e":  "1403 1 ش",
+	} {
+		if p, err := Parse(layout, value); err == nil {
+			t.Error(
+				"For", value,
+				"expected", "error",
+				"got", p.String(),
+			)
+		}
+	}
+}
+
+func TestParseRFC3339(t *testing.T) {
+	for _, loc := range []*time.Location{Iran(), Afghanistan(), time.UTC} {
+		for _, nsec := range []int{0, 1, 50260050, 500000000, 999999999} {
+			for _, ti := range []Time{
+				Date(1394, Mehr, 2, 12, 59, 59, nsec, loc),
+				Date(1403, Esfand, 30, 23, 59, 59, nsec, loc),
+				Date(1367, Tir, 1, 0, 0, 0, nsec, loc),
+				Date(1420, Farvardin, 1, 0, 0, 0, nsec, loc),
+			} {
+				p, err := ParseRFC3339(ti.RFC3339())
+				if err != nil || !p.Time().Equal(ti.Time()) || p.String() != ti.String() {
+					t.Error(
+						"For", ti.RFC3339(),
+						"expected", ti.String(),
+						"got", p.String(), err,
+					)
+				}
+			}
+		}
+	}
+
+	if p, _ := ParseRFC3339("1394-07-02T12:59:59+03:30"); p.Location().String() != "Asia/Tehran" {
+		t.Error(
+			"For", "1394-07-02T12:59:59+03:30",
+			"expected", "Asia/Tehran",
+			"got", p.Location(),
+		)
+	}
+
+	for _, s := range []string{"1394-07-02T12:59:59", "1394-07-02T12:59:59.Z", "1394-07-02 12:59:59Z", "1394-07-02T12:59:59.1234567890Z", "1394-07-02T12:59:59.5+3:30"} {
+		if p, err := ParseRFC3339(s); err == nil {
+			t.Error(
+				"For", s,
+				"expected", "error",
+				"got", p.String(),
+			)
+		}
+	}
+
+	var perr *ParseError
+	if _, err := ParseRFC3339("1394-07-02T12:59:59.5+3:30"); !errors.As(err, &perr) || perr.Value[perr.Position:] != "+3:30" {
+		t.Error(
+			"For", "1394-07-02T12:59:59.5+3:30",
+			"expected", "+3:30",
+			"got", err,
+		)
+	}
+}
+
+func TestParseGregorian(t *testing.T) {
+	ti, err := ParseGregorian(time.RFC3339Nano, "2015-09-24T12:59:59.05026005+03:30", Iran())
+	if err != nil || ti.String() != "1394-07-02T12:59:59.05026005+03:30" {
+		t.Error(
+			"For", "2015-09-24T12:59:59.05026005+03:30",
+			"expected", "1394-07-02T12:59:59.05026005+03:30",
+			"got", ti.String(), err,
+		)
+	}
+
+	ti, err = ParseGregorian(time.RFC3339, "2025-03-20T21:00:00Z", time.UTC)
+	if err != nil || ti.Format("yyyy/MM/dd HH:mm") != "1403/12/30 21:00" {
+		t.Error(
+			"For", "2025-03-20T21:00:00Z",
+			"expected", "1403/12/30 21:00",
+			"got", ti.Format("yyyy/MM/dd HH:mm"), err,
+		)
+	}
+
+	ti, err = ParseGregorian("2006-01-02 15:04", "2025-03-21 08:30", Iran())
+	if err != nil || ti.String() != "1404-01-01T08:30:00+03:30" || ti.Location().String() != "Asia/Tehran" {
+		t.Error(
+			"For", "2025-03-21 08:30",
+			"expected", "1404-01-01T08:30:00+03:30",
+			"got", ti.String(), err,
+		)
+	}
+
+	if _, err := ParseGregorian(time.RFC3339, "1394-13-02T12:59:59Z", Iran()); err == nil {
+		t.Error(
+			"For", "1394-13-02T12:59:59Z",
+			"expected", "error",
+			"got", nil,
+		)
+	}
+}
+
+func TestNormalizePersian(t *testing.T) {
+	vals := map[string]string{
+		"\u062f\u064a": "دی",
+		"\u062f\u0649": "دی",
+		"\u064a\u0643\u200c\u0634\u0646\u0628\u0647": "یک\u200cشنبه",
+		"یک\u200c\u200cشنبه":                         "یک\u200cشنبه",
+		"\u200cیک\u200c شنبه\u200c":                  "یک شنبه",
+		"1403/01/01":                                 "1403/01/01",
+	}
+
+	for s, expected := range vals {
+		if n := NormalizePersian(s); n != expected {
+			t.Error(
+				"For", fmt.Sprintf("%q", s),
+				"expected", fmt.Sprintf("%q", expected),
+				"got", fmt.Sprintf("%q", n),
+			)
+		}
+	}
+}
+
+func TestParseMonthWeekday(t *testing.T) {
+	months := map[string]Month{
+		"دی":             Dey,
+		"\u062f\u064a":   Dey,
+		" \u062f\u0649 ": Dey,
+		"حمل":            Farvardin,
+		"esfand":         Esfand,
+		"ارديبهشت":       Ordibehesht,
+	}
+	for s, expected := range months {
+		if m, err := ParseMonth(s); err != nil || m != expected {
+			t.Error(
+				"For", fmt.Sprintf("%q", s),
+				"expected", expected,
+				"got", m, err,
+			)
+		}
+	}
+
+	weekdays := map[string]Weekday{
+		"یک\u200cشنبه":                               Yekshanbeh,
+		"\u064a\u0643\u200c\u0634\u0646\u0628\u0647": Yekshanbeh,
+		"\u064a\u0643\u0634\u0646\u0628\u0647":       Yekshanbeh,
+		"یک\u200c\u200cشنبه":                         Yekshanbeh,
+		"یک شنبه":                                    Yekshanbeh,
+		"ی":                                          Yekshanbeh,
+		"شنبه":                                       Shanbeh,
+		"JOMEH":                                      Jomeh,
+	}
+	for s, expected := range weekdays {
+		if wd, err := ParseWeekday(s); err != nil || wd != expected {
+			t.Error(
+				"For", fmt.Sprintf("%q", s),
+				"expected", expected,
+				"got", wd, err,
+			)
+		}
+	}
+
+	if m, err := ParseMonth("دیروز"); err == nil {
+		t.Error(
+			"For", "دیروز",
+			"expected", "error",
+			"got", m,
+		)
+	}
+	if wd, err := ParseWeekday(""); err == nil {
+		t.Error(
+			"For", "empty string",
+			"expected", "error",
+			"got", wd,
+		)
+	}
+}
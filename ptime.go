@@ -7,6 +7,9 @@
 // This source code is licensed under MIT license that can be found in the LICENSE file.
 
 // Package ptime provides functionality for implementation of Persian (Solar Hijri) Calendar.
+//
+// Like the time package, the Gregorian dates are in the proleptic Gregorian calendar,
+// i.e. the dates before the Gregorian reform of October 1582 are not converted to the Julian calendar.
 package ptime
 
 import (
@@ -14,7 +17,9 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 )
 
 // A Month specifies a month of the year starting from Farvardin = 1.
@@ -26,6 +31,12 @@ type Weekday int
 // A AmPm specifies the 12-Hour marker.
 type AmPm int
 
+// An OverflowMode specifies how AddMonthsMode handles a day which does not exist in the resulting month.
+type OverflowMode int
+
+// A Unit specifies the unit of time in which DiffIn measures a difference.
+type Unit int
+
 // A Time represents a moment in time in Persian (Jalali) Calendar.
 type Time struct {
 	year  int
@@ -88,6 +99,31 @@ const (
 	Pm
 )
 
+// List of overflow modes.
+const (
+	// ClampDay clamps the day to the last day of the resulting month,
+	// e.g. Shahrivar 31 + 1 month is Mehr 30.
+	ClampDay OverflowMode = iota
+	// RollOver spills the overflowing days into the next month as AddDate does,
+	// e.g. Shahrivar 31 + 1 month is Aban 1.
+	RollOver
+)
+
+// List of units of time.
+const (
+	UnitYear Unit = iota
+	UnitMonth
+	UnitWeek
+	UnitDay
+	UnitHour
+	UnitMinute
+	UnitSecond
+)
+
+// configMu guards the package-level configuration, i.e. amPm, sAmPm, nowFunc and
+// defaultLoc in this file and valueMode in encoding.go.
+var configMu sync.RWMutex
+
 var amPm = [2]string{
 	"قبل از ظهر",
 	"بعد از ظهر",
@@ -98,6 +134,11 @@ var sAmPm = [2]string{
 	"ب.ظ",
 }
 
+var enAmPm = [2]string{
+	"AM",
+	"PM",
+}
+
 var months = [12]string{
 	"فروردین",
 	"اردیبهشت",
@@ -128,6 +169,36 @@ var dmonths = [12]string{
 	"حوت",
 }
 
+var lmonths = [12]string{
+	"Farvardin",
+	"Ordibehesht",
+	"Khordad",
+	"Tir",
+	"Mordad",
+	"Shahrivar",
+	"Mehr",
+	"Aban",
+	"Azar",
+	"Dey",
+	"Bahman",
+	"Esfand",
+}
+
+var gmonths = [12]string{
+	"ژانویه",
+	"فوریه",
+	"مارس",
+	"آوریل",
+	"مه",
+	"ژوئن",
+	"ژوئیه",
+	"اوت",
+	"سپتامبر",
+	"اکتبر",
+	"نوامبر",
+	"دسامبر",
+}
+
 var days = [7]string{
 	"شنبه",
 	"یک‌شنبه",
@@ -148,6 +219,16 @@ var sdays = [7]string{
 	"ج",
 }
 
+var ldays = [7]string{
+	"Shanbeh",
+	"Yekshanbeh",
+	"Doshanbeh",
+	"Seshanbeh",
+	"Charshanbeh",
+	"Panjshanbeh",
+	"Jomeh",
+}
+
 //  {days, leap_days, days_before_start}
 var pMonthCount = [12][3]int{
 	{31, 31, 0},   // Farvardin
@@ -182,9 +263,49 @@ func Afghanistan() *time.Location {
 	return loc
 }
 
-// String returns t in RFC3339Nano format.
+// String returns t in RFC3339Nano format (e.g. 1394-07-02T12:59:59.05026+03:30).
+//
+// The fraction of second is omitted if it is zero and the trailing zeros are removed.
 func (t Time) String() string {
-	return t.Format("yyyy-MM-ddTHH:mm:ss.nsZ")
+	s := fmt.Sprintf("%04d-%02d-%02dT%02d:%02d:%02d", t.year, t.month, t.day, t.hour, t.min, t.sec)
+	return s + t.shortFraction() + t.ZoneOffset("Z07:00")
+}
+
+// RFC3339 returns t in RFC3339Nano format, the same as String.
+// It is the inverse of ParseRFC3339, except for the seconds of zone offsets
+// (e.g. local mean times) which are not representable in RFC 3339.
+func (t Time) RFC3339() string {
+	return t.String()
+}
+
+// PersianString returns t in the format of yyyy/MM/dd HH:mm:ss with Persian digits
+// (e.g. ۱۳۹۴/۰۷/۰۲ ۱۲:۵۹:۵۹), which is suitable for displaying to users.
+func (t Time) PersianString() string {
+	return toPersianDigits(t.Format("yyyy/MM/dd HH:mm:ss"))
+}
+
+// Pad returns t in the format of yyyy/MM/dd HH:mm:ss with ASCII digits, where every field
+// is zero-padded (e.g. 0800/01/02 03:04:05). For the years 0 to 9999, the result is always
+// 19 characters long and the lexical order of the results of the times in the same location
+// is their chronological order, e.g. for logs and sorting.
+func (t Time) Pad() string {
+	return fmt.Sprintf("%04d/%02d/%02d %02d:%02d:%02d", t.year, t.month, t.day, t.hour, t.min, t.sec)
+}
+
+// TimeString returns the clock of t in the format of HH:mm:ss with Persian digits (e.g. ۱۴:۰۵:۰۹).
+func (t Time) TimeString() string {
+	return toPersianDigits(t.Format("HH:mm:ss"))
+}
+
+// ClockFa returns the clock of t in the format of HH:mm with Persian digits (e.g. ۱۴:۰۵).
+func (t Time) ClockFa() string {
+	return toPersianDigits(t.Format("HH:mm"))
+}
+
+// ClockFa12 returns the clock of t in the 12-hour format of h:mm a with Persian digits
+// (e.g. ۲:۰۵ ب.ظ).
+func (t Time) ClockFa12() string {
+	return toPersianDigits(t.Format("h:mm a"))
 }
 
 // Dari returns the Dari name of the month.
@@ -197,6 +318,46 @@ func (m Month) String() string {
 	return months[m-1]
 }
 
+// Latin returns the Latin transliteration of the Persian name of the month (e.g. Farvardin).
+func (m Month) Latin() string {
+	return lmonths[m-1]
+}
+
+// Length returns the number of days in the month m in a leap or a non-leap year.
+//
+// It panics if m is not in the range [Farvardin, Esfand].
+func (m Month) Length(leap bool) int {
+	if m < Farvardin || m > Esfand {
+		panic("ptime: month out of range in call to Length")
+	}
+
+	if leap {
+		return pMonthCount[m-1][1]
+	}
+	return pMonthCount[m-1][0]
+}
+
+// DaysBeforeStart returns the number of days of the year before the first day of the month m.
+//
+// It panics if m is not in the range [Farvardin, Esfand].
+func (m Month) DaysBeforeStart() int {
+	if m < Farvardin || m > Esfand {
+		panic("ptime: month out of range in call to DaysBeforeStart")
+	}
+
+	return pMonthCount[m-1][2]
+}
+
+// Next returns the month after m, wrapping around from Esfand to Farvardin.
+func (m Month) Next() Month {
+	return Month(divider(int(m), 12) + 1)
+}
+
+// Prev returns the month before m, wrapping around from Farvardin to Esfand.
+func (m Month) Prev() Month {
+	return Month(divider(int(m)-2, 12) + 1)
+}
+
 // String returns the Persian name of the day in week.
 func (d Weekday) String() string {
 	return days[d]
@@ -207,16 +368,112 @@ func (d Weekday) Short() string {
 	return sdays[d]
 }
 
+// Latin returns the Latin transliteration of the Persian name of the day in week (e.g. Shanbeh).
+func (d Weekday) Latin() string {
+	return ldays[d]
+}
+
+// Next returns the day after d, wrapping around from Jomeh to Shanbeh.
+func (d Weekday) Next() Weekday {
+	return Weekday(divider(int(d)+1, 7))
+}
+
+// Prev returns the day before d, wrapping around from Shanbeh to Jomeh.
+func (d Weekday) Prev() Weekday {
+	return Weekday(divider(int(d)-1, 7))
+}
+
+// Months returns the list of months from Farvardin to Esfand.
+func Months() []Month {
+	m := make([]Month, 12)
+	for i := range m {
+		m[i] = Farvardin + Month(i)
+	}
+	return m
+}
+
+// MonthNames returns the Persian names of months from Farvardin to Esfand.
+func MonthNames() []string {
+	return append([]string(nil), months[:]...)
+}
+
+// DariMonthNames returns the Dari names of months from Hamal to Hut.
+func DariMonthNames() []string {
+	return append([]string(nil), dmonths[:]...)
+}
+
+// Weekdays returns the list of days in a week from Shanbeh to Jomeh.
+func Weekdays() []Weekday {
+	d := make([]Weekday, 7)
+	for i := range d {
+		d[i] = Weekday(i)
+	}
+	return d
+}
+
+// WeekdayNames returns the Persian names of days in a week from Shanbeh to Jomeh.
+func WeekdayNames() []string {
+	return append([]string(nil), days[:]...)
+}
+
+// ToStdWeekday returns the corresponding time.Weekday of d.
+func (d Weekday) ToStdWeekday() time.Weekday {
+	return time.Weekday((d + 6) % 7)
+}
+
+// GregorianWeekday returns the day of week of t as a time.Weekday (e.g. time.Wednesday for Charshanbeh).
+func (t Time) GregorianWeekday() time.Weekday {
+	return t.wday.ToStdWeekday()
+}
+
+// FromStdWeekday returns the corresponding Weekday of wd.
+//
+// FromStdWeekday panics if wd is not in the range of time.Sunday to time.Saturday.
+func FromStdWeekday(wd time.Weekday) Weekday {
+	if wd < time.Sunday || wd > time.Saturday {
+		panic("ptime: weekday out of range in call to FromStdWeekday")
+	}
+	return getWeekday(wd)
+}
+
 // String returns the Persian name of 12-Hour marker.
 func (a AmPm) String() string {
+	configMu.RLock()
+	defer configMu.RUnlock()
 	return amPm[a]
 }
 
 // Short returns the Persian short name of 12-Hour marker.
 func (a AmPm) Short() string {
+	configMu.RLock()
+	defer configMu.RUnlock()
 	return sAmPm[a]
 }
 
+// EnglishShort returns the English short name of 12-Hour marker (AM or PM).
+func (a AmPm) EnglishShort() string {
+	return enAmPm[a]
+}
+
+// SetAmPmNames overrides the Persian names of 12-Hour markers
+// returned by String and Short methods of AmPm (e.g. صبح and عصر).
+//
+// am and pm are the full names, shortAm and shortPm are the short names.
+// It is safe to call SetAmPmNames concurrently with other functions of the package.
+func SetAmPmNames(am, pm, shortAm, shortPm string) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	amPm = [2]string{am, pm}
+	sAmPm = [2]string{shortAm, shortPm}
+}
+
+// amPmNames returns the current full and short names of 12-Hour markers.
+func amPmNames() (full, short [2]string) {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return amPm, sAmPm
+}
+
 // New converts Gregorian calendar to Persian calendar and
 //
 // returns a new instance of Time corresponding to the time of t.
@@ -230,36 +487,59 @@ func New(t time.Time) Time {
 }
 
 // Time converts Persian date to Gregorian date and returns a new instance of time.Time
+//
+// If the location of t is nil (e.g. the zero value of Time), the returned time is in UTC.
 func (t Time) Time() time.Time {
-	var year, month, day int
-
-	jdn := getJdn(t.year, int(t.month), t.day)
-
-	if jdn > 2299160 {
-		l := jdn + 68569
-		n := 4 * l / 146097
-		l = l - (146097*n+3)/4
-		i := 4000 * (l + 1) / 1461001
-		l = l - 1461*i/4 + 31
-		j := 80 * l / 2447
-		day = l - 2447*j/80
-		l = j / 11
-		month = j + 2 - 12*l
-		year = 100*(n-49) + i + l
-	} else {
-		j := jdn + 1402
-		k := (j - 1) / 1461
-		l := j - 1461*k
-		n := (l-1)/365 - l/1461
-		i := l - 365*n + 30
-		j = 80 * i / 2447
-		day = i - 2447*j/80
-		i = j / 11
-		month = j + 2 - 12*i
-		year = 4*k + n + i - 4716
+	year, month, day := gregorianDate(getJdn(t.year, int(t.month), t.day))
+
+	loc := t.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return time.Date(year, time.Month(month), day, t.hour, t.min, t.sec, t.nsec, loc)
+}
+
+// TimeInLocation returns the Gregorian equivalent of t in the location loc.
+// The returned time represents the same instant as t.
+//
+// loc is a pointer to time.Location and must not be nil.
+func (t Time) TimeInLocation(loc *time.Location) time.Time {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to TimeInLocation")
 	}
 
-	return time.Date(year, time.Month(month), day, t.hour, t.min, t.sec, t.nsec, t.loc)
+	return t.Time().In(loc)
+}
+
+// DateTime returns the Gregorian equivalent of the date of t at 00:00:00 in the location of t
+// as a new instance of time.Time, i.e. the clock of t is stripped.
+func (t Time) DateTime() time.Time {
+	return t.BeginningOfDay().Time()
+}
+
+// DateFromGregorian returns a new instance of Time representing the date of g at 00:00:00
+// in the location of g, i.e. the clock of g is stripped.
+func DateFromGregorian(g time.Time) Time {
+	return New(time.Date(g.Year(), g.Month(), g.Day(), 0, 0, 0, 0, g.Location()))
+}
+
+// GregorianWallClock returns the Gregorian equivalent of t as a new instance of time.Time.
+//
+// The returned time has the same hour, minute, second, nanosecond and location as t,
+// therefore both represent the same instant. It is the same as calling t.Time().
+// FromGregorianWallClock is its inverse and no information is lost in either direction.
+func (t Time) GregorianWallClock() time.Time {
+	return t.Time()
+}
+
+// FromGregorianWallClock returns the Persian equivalent of g as a new instance of Time.
+//
+// The returned time has the same hour, minute, second, nanosecond and location as g,
+// therefore both represent the same instant. It is the same as calling New(g).
+// GregorianWallClock is its inverse and no information is lost in either direction.
+func FromGregorianWallClock(g time.Time) Time {
+	return New(g)
 }
 
 // Date returns a new instance of Time.
@@ -280,6 +560,35 @@ func Date(year int, month Month, day, hour, min, sec, nsec int, loc *time.Locati
 	return *t
 }
 
+// FromGregorian returns a new instance of Time corresponding to a Gregorian date and time.
+//
+// gy, gm and gd represent a day in Gregorian calendar.
+//
+// hour, min minute, sec seconds, nsec nanoseconds offsets represent a moment in time.
+//
+// loc is a pointer to time.Location and must not be nil.
+//
+// Unlike Date, the values are not normalized and FromGregorian panics if any of them is out of its range.
+func FromGregorian(gy, gm, gd, hour, min, sec, nsec int, loc *time.Location) Time {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to FromGregorian")
+	}
+
+	if gm < 1 || gm > 12 {
+		panic("ptime: month out of range in call to FromGregorian")
+	}
+
+	if gd < 1 || gd > time.Date(gy, time.Month(gm)+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+		panic("ptime: day out of range in call to FromGregorian")
+	}
+
+	if hour < 0 || hour > 23 || min < 0 || min > 59 || sec < 0 || sec > 59 || nsec < 0 || nsec > 999999999 {
+		panic("ptime: clock out of range in call to FromGregorian")
+	}
+
+	return New(time.Date(gy, time.Month(gm), gd, hour, min, sec, nsec, loc))
+}
+
 // Unix returns a new instance of PersianDate from unix timestamp.
 //
 // sec seconds and nsec nanoseconds since January 1, 1970 UTC.
@@ -301,55 +610,75 @@ func Now(loc *time.Location) Time {
 		panic("ptime: the Location must not be nil in call to Now")
 	}
 
-	return New(time.Now().In(loc))
+	return New(now().In(loc))
 }
 
-// SetTime sets t to the time of ti.
-func (t *Time) SetTime(ti time.Time) {
-	var year, month, day int
-
-	t.nsec = ti.Nanosecond()
-	t.sec = ti.Second()
-	t.min = ti.Minute()
-	t.hour = ti.Hour()
-	t.loc = ti.Location()
-	t.wday = getWeekday(ti.Weekday())
+// now returns the current time by nowFunc.
+func now() time.Time {
+	configMu.RLock()
+	f := nowFunc
+	configMu.RUnlock()
+	return f()
+}
 
-	var jdn int
-	gy, gmm, gd := ti.Date()
-	gm := int(gmm)
+// nowFunc returns the current time and is used by all functions which depend on it.
+var nowFunc = time.Now
 
-	if gy > 1582 || (gy == 1582 && gm > 10) || (gy == 1582 && gm == 10 && gd > 14) {
-		jdn = ((1461 * (gy + 4800 + ((gm - 14) / 12))) / 4) + ((367 * (gm - 2 - 12*((gm-14)/12))) / 12) - ((3 * ((gy + 4900 + ((gm - 14) / 12)) / 100)) / 4) + gd - 32075
-	} else {
-		jdn = 367*gy - ((7 * (gy + 5001 + ((gm - 9) / 7))) / 4) + ((275 * gm) / 9) + gd + 1729777
+// SetNowFunc sets the function which returns the current time (time.Now by default).
+// Passing nil restores the default.
+//
+// It is intended for freezing the current time in tests and
+// it is safe to call it concurrently with other functions of the package.
+func SetNowFunc(f func() time.Time) {
+	if f == nil {
+		f = time.Now
 	}
 
-	dep := jdn - getJdn(475, 1, 1)
-	cyc := dep / 1029983
-	rem := dep % 1029983
+	configMu.Lock()
+	defer configMu.Unlock()
+	nowFunc = f
+}
 
-	var ycyc int
-	if rem == 1029982 {
-		ycyc = 2820
-	} else {
-		a := rem / 366
-		ycyc = (2134*a+2816*(rem%366)+2815)/1028522 + a + 1
-	}
+// defaultLoc is the location returned by DefaultLocation, or nil for the location of Iran.
+var defaultLoc *time.Location
 
-	year = ycyc + 2820*cyc + 474
-	if year <= 0 {
-		year = year - 1
-	}
+// DefaultLocation returns the location in which the functions without a location argument
+// interpret the times without a location or a zone offset, i.e. Parse, ParseRFC3339,
+// HolidaysInMonth and the Scan method. It is the location of Iran by default.
+func DefaultLocation() *time.Location {
+	configMu.RLock()
+	loc := defaultLoc
+	configMu.RUnlock()
 
-	var dy = float64(jdn - getJdn(year, 1, 1) + 1)
-	if dy <= 186 {
-		month = int(math.Ceil(dy / 31.0))
-	} else {
-		month = int(math.Ceil((dy - 6) / 30.0))
+	if loc == nil {
+		return Iran()
 	}
+	return loc
+}
 
-	day = jdn - getJdn(year, month, 1) + 1
+// SetDefaultLocation sets the location returned by DefaultLocation.
+// Passing nil restores the default, i.e. the location of Iran.
+//
+// It is intended to be called once during the initialization of a program. It is safe
+// to call it concurrently with other functions of the package, but the functions which
+// are running at the same time may use either the old or the new location.
+func SetDefaultLocation(loc *time.Location) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	defaultLoc = loc
+}
+
+// SetTime sets t to the time of ti.
+func (t *Time) SetTime(ti time.Time) {
+	t.nsec = ti.Nanosecond()
+	t.sec = ti.Second()
+	t.min = ti.Minute()
+	t.hour = ti.Hour()
+	t.loc = ti.Location()
+	t.wday = getWeekday(ti.Weekday())
+
+	gy, gm, gd := ti.Date()
+	year, month, day := getDate(gregorianJdn(gy, int(gm), gd))
 
 	t.year = year
 	t.month = Month(month)
@@ -386,23 +715,6 @@ func norm(hi, lo, base int) (int, int) {
 	return hi, lo
 }
 
-// norm returns nhi, nlo such that
-//	hi * base + lo == nhi * base + nlo
-//	0 <= nlo < base
-func normDay(hi, lo, base int) (int, int) {
-	if lo < 1 {
-		n := (-lo-1)/base + 1
-		hi -= n
-		lo += n * base
-	}
-	if lo > base {
-		n := lo / base
-		hi += n
-		lo -= n * base
-	}
-	return hi, lo
-}
-
 // Set sets t.
 //
 // year, month and day represent a day in Persian calendar.
@@ -424,16 +736,12 @@ func (t *Time) Set(year int, month Month, day, hour, min, sec, nsec int, loc *ti
 	// Normalize month, overflowing into year.
 	m := int(month) - 1
 	year, m = norm(year, m, 12)
-	if isLeap(year) {
-		m, day = normDay(m, day, pMonthCount[m][1])
-	} else {
-		m, day = normDay(m, day, pMonthCount[m][0])
-	}
-	year, m = norm(year, m, 12)
-	month = Month(m) + 1
-	t.year = year
-	t.month = month
-	t.day = day
+
+	// Normalize day, overflowing into month and year.
+	y, mm, d := getDate(getJdn(year, m+1, 1) + day - 1)
+	t.year = y
+	t.month = Month(mm)
+	t.day = d
 	t.hour = hour
 	t.min = min
 	t.sec = sec
@@ -490,6 +798,59 @@ func (t *Time) SetNanosecond(nsec int) {
 	t.normNanosecond()
 }
 
+// WithYear returns a copy of t with the year set to year, normalized as SetYear.
+func (t Time) WithYear(year int) Time {
+	t.SetYear(year)
+	return t
+}
+
+// WithMonth returns a copy of t with the month set to month, normalized as SetMonth.
+func (t Time) WithMonth(month Month) Time {
+	t.SetMonth(month)
+	return t
+}
+
+// WithDay returns a copy of t with the day set to day, normalized as SetDay.
+func (t Time) WithDay(day int) Time {
+	t.SetDay(day)
+	return t
+}
+
+// WithHour returns a copy of t with the hour set to hour, normalized as SetHour.
+func (t Time) WithHour(hour int) Time {
+	t.SetHour(hour)
+	return t
+}
+
+// WithMinute returns a copy of t with the minute offset set to min, normalized as SetMinute.
+func (t Time) WithMinute(min int) Time {
+	t.SetMinute(min)
+	return t
+}
+
+// WithSecond returns a copy of t with the second offset set to sec, normalized as SetSecond.
+func (t Time) WithSecond(sec int) Time {
+	t.SetSecond(sec)
+	return t
+}
+
+// WithNanosecond returns a copy of t with the nanosecond offset set to nsec, normalized as SetNanosecond.
+func (t Time) WithNanosecond(nsec int) Time {
+	t.SetNanosecond(nsec)
+	return t
+}
+
+// InYear returns a new instance of Time representing the month, the day and the clock of t
+// in the year, keeping the location of t. Esfand 30 is clamped to Esfand 29 if the year
+// is not a leap year, e.g. for the anniversaries of a date.
+func (t Time) InYear(year int) Time {
+	day := t.day
+	if n := monthLength(year, t.month); day > n {
+		day = n
+	}
+	return Date(year, t.month, day, t.hour, t.min, t.sec, t.nsec, t.loc)
+}
+
 // In sets the location of t.
 //
 // loc is a pointer to time.Location and must not be nil.
@@ -502,6 +863,14 @@ func (t *Time) In(loc *time.Location) {
 	t.resetWeekday()
 }
 
+// ToUTC returns a new instance of Time representing the same instant as t in UTC.
+//
+// Unlike In, which only changes the location and keeps the date and clock of t,
+// ToUTC converts the date and clock, so it is suitable for normalizing times of different locations.
+func (t Time) ToUTC() Time {
+	return New(t.Time().UTC())
+}
+
 // At sets the hour, min minute, sec second and nsec nanoseconds offsets of t.
 func (t *Time) At(hour, min, sec, nsec int) {
 	t.SetHour(hour)
@@ -515,11 +884,29 @@ func (t Time) Unix() int64 {
 	return t.Time().Unix()
 }
 
+// UnixDay returns the number of seconds since January 1, 1970 UTC at the beginning of
+// the day of t in the location of t, e.g. for grouping times by their Persian day.
+func (t Time) UnixDay() int64 {
+	return t.BeginningOfDay().Unix()
+}
+
 // UnixNano seturns the number of nanoseconds since January 1, 1970 UTC.
 func (t Time) UnixNano() int64 {
 	return t.Time().UnixNano()
 }
 
+// SortKey returns an integer key of t such that t is before u if and only if
+// t.SortKey() < u.SortKey(). When sorting many values, computing the key of each value
+// once is much cheaper than comparing t.Time().Before(u.Time()) repeatedly.
+// The key is the same as UnixNano, so it does not lose precision and does not depend
+// on the location of t.
+//
+// The key does not overflow for instants between 1677-09-21 and 2262-04-11 in the Gregorian
+// calendar (about 1056/06/31 to 1641/01/22 in the Persian calendar), the range of UnixNano.
+func (t Time) SortKey() int64 {
+	return t.UnixNano()
+}
+
 // Date returns the year, month, day of t.
 func (t Time) Date() (int, Month, int) {
 	return t.year, t.month, t.day
@@ -587,11 +974,127 @@ func (t Time) YearDay() int {
 
 // RYearDay returns the number of remaining days of the year of t.
 func (t Time) RYearDay() int {
-	y := 365
-	if t.IsLeap() {
-		y++
+	return t.DaysInYear() - t.YearDay()
+}
+
+// DaysInYear returns the number of days in the year of t (365 or 366).
+func (t Time) DaysInYear() int {
+	return YearLength(t.year)
+}
+
+// DateFromYearDay returns a new instance of Time representing the day yearDay of the year
+// at 00:00:00 in the location loc. It is the inverse of YearDay.
+//
+// An error is returned if yearDay is not in the range [1, YearLength(year)].
+//
+// loc is a pointer to time.Location and must not be nil.
+func DateFromYearDay(year, yearDay int, loc *time.Location) (Time, error) {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to DateFromYearDay")
+	}
+
+	if n := YearLength(year); yearDay < 1 || yearDay > n {
+		return Time{}, fmt.Errorf("ptime: day of year %d out of range [1, %d] for year %d", yearDay, n, year)
+	}
+
+	y, m, d := getDate(nowruzJdn(year) + yearDay - 1)
+	return Date(y, Month(m), d, 0, 0, 0, 0, loc), nil
+}
+
+// YearRange returns the first instant (Farvardin 1 at 00:00:00) and the last instant
+// (Esfand 29 or 30 at 23:59:59.999999999) of the year in the location loc.
+//
+// loc is a pointer to time.Location and must not be nil.
+func YearRange(year int, loc *time.Location) (start, end Time) {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to YearRange")
+	}
+
+	start = Date(year, Farvardin, 1, 0, 0, 0, 0, loc)
+	return start, start.EndOfYear()
+}
+
+// MonthRange returns the first instant (the first day at 00:00:00) and the last instant
+// (the last day at 23:59:59.999999999) of the month of the year in the location loc.
+// It panics if month is not in the range [Farvardin, Esfand].
+//
+// loc is a pointer to time.Location and must not be nil.
+func MonthRange(year int, month Month, loc *time.Location) (start, end Time) {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to MonthRange")
+	}
+	if month < Farvardin || month > Esfand {
+		panic("ptime: month out of range in call to MonthRange")
+	}
+
+	start = Date(year, month, 1, 0, 0, 0, 0, loc)
+	return start, start.EndOfMonth()
+}
+
+// MonthMatrix returns the days of the month of the year at 00:00:00 in the location loc,
+// laid out in a 6×7 grid of weeks where each column is a weekday from Shanbeh to Jomeh.
+// The first row contains the first day of the month.
+//
+// If fillAdjacent is true, the cells before and after the month are filled with the days
+// of the previous and next months. Otherwise, they are the zero Time.
+// It panics if month is not in the range [Farvardin, Esfand].
+//
+// loc is a pointer to time.Location and must not be nil.
+func MonthMatrix(year int, month Month, loc *time.Location, fillAdjacent bool) [6][7]Time {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to MonthMatrix")
+	}
+	if month < Farvardin || month > Esfand {
+		panic("ptime: month out of range in call to MonthMatrix")
+	}
+
+	first := getJdn(year, int(month), 1)
+	last := first + monthLength(year, month) - 1
+	jdn := first - int(jdnWeekday(first))
+
+	var grid [6][7]Time
+	for w := range grid {
+		for d := range grid[w] {
+			if fillAdjacent || (jdn >= first && jdn <= last) {
+				y, m, day := getDate(jdn)
+				grid[w][d] = Date(y, Month(m), day, 0, 0, 0, 0, loc)
+			}
+			jdn++
+		}
+	}
+	return grid
+}
+
+// YearLength returns the number of days in the year (365 or 366).
+func YearLength(year int) int {
+	if isLeap(year) {
+		return 366
 	}
-	return y - t.YearDay()
+	return 365
+}
+
+// IsSupportedYear reports whether the dates of the year are guaranteed to match the official
+// calendar of Iran, i.e. year is in the range [1178, 1633] (from 1799-03-21 to 2255-03-20).
+//
+// The conversions use the 33-year arithmetic rule of IsLeap, which is exact for any year
+// in its own terms but agrees with the astronomical rule of the official calendar
+// (see IsLeapAstronomical) only in this range. Outside of it, the dates may be one day off.
+func IsSupportedYear(year int) bool {
+	return year >= 1178 && year <= 1633
+}
+
+// LeapYears returns the leap years from the year from to the year to, inclusive,
+// by the 33-year arithmetic rule of IsLeap. See LeapYearsAstronomical for the astronomical rule.
+//
+// It returns nil if to is before from.
+func LeapYears(from, to int) []int {
+	var years []int
+	for y := from; y <= to; y++ {
+		if isLeap(y) {
+			years = append(years, y)
+		}
+	}
+	return years
 }
 
 // Weekday returns the weekday of t.
@@ -608,6 +1111,27 @@ func (t Time) RMonthDay() int {
 	return pMonthCount[t.month-1][i] - t.day
 }
 
+// BeginningOfDay returns a new instance of Time representing the day of t at 00:00:00.
+func (t Time) BeginningOfDay() Time {
+	return Date(t.year, t.month, t.day, 0, 0, 0, 0, t.loc)
+}
+
+// EndOfDay returns a new instance of Time representing the day of t at 23:59:59.999999999.
+func (t Time) EndOfDay() Time {
+	return Date(t.year, t.month, t.day, 23, 59, 59, 999999999, t.loc)
+}
+
+// GregorianDayRange returns the half-open range [start, end) of the instants of the day of t
+// in the location of t, where start is the beginning of the day and end is the beginning
+// of the next day, e.g. for querying timestamps which are stored in UTC.
+//
+// The range is 24 hours long, except on the days of daylight saving time transitions.
+func (t Time) GregorianDayRange() (start, end time.Time) {
+	start = t.BeginningOfDay().Time()
+	end = Date(t.year, t.month, t.day+1, 0, 0, 0, 0, t.loc).Time()
+	return start, end
+}
+
 // BeginningOfWeek returns a new instance of Time representing the first day of the week of t.
 // The time is reset to 00:00:00
 func (t Time) BeginningOfWeek() Time {
@@ -636,12 +1160,74 @@ func (t Time) LastWeekday() Time {
 	return t.AddDate(0, 0, int(Jomeh-t.wday))
 }
 
+// FirstWeekDayFrom returns a new instance of Time representing the first day of the week of t
+// for the weeks which start on the weekday start.
+func (t Time) FirstWeekDayFrom(start Weekday) Time {
+	return t.AddDate(0, 0, -divider(int(t.wday-start), 7))
+}
+
+// LastWeekDayFrom returns a new instance of Time representing the last day of the week of t
+// for the weeks which start on the weekday start.
+func (t Time) LastWeekDayFrom(start Weekday) Time {
+	return t.AddDate(0, 0, 6-divider(int(t.wday-start), 7))
+}
+
+// SameWeek reports whether t and u fall in the same week (Shanbeh to Jomeh),
+// where u is converted to the location of t.
+func (t Time) SameWeek(u Time) bool {
+	return t.SameWeekFrom(u, Shanbeh)
+}
+
+// SameWeekFrom is like SameWeek but the weeks start on the weekday start.
+func (t Time) SameWeekFrom(u Time, start Weekday) bool {
+	weekStart := func(jdn int) int {
+		return jdn - divider(int(jdnWeekday(jdn)-start), 7)
+	}
+	return weekStart(t.jdn()) == weekStart(New(u.Time().In(t.Time().Location())).jdn())
+}
+
+// LastWeekdayOfMonth returns a new instance of Time representing the last day of the month of t
+// which falls on the weekday wd, keeping the clock and the location of t.
+func (t Time) LastWeekdayOfMonth(wd Weekday) Time {
+	last := t.LastMonthDay()
+	return last.AddDate(0, 0, -divider(int(last.wday-wd), 7))
+}
+
+// OnWeekday returns a new instance of Time representing the weekday wd of the week of t,
+// keeping the clock and the location of t. The week starts on Shanbeh and ends on Jomeh.
+func (t Time) OnWeekday(wd Weekday) Time {
+	return t.AddDate(0, 0, int(wd-t.wday))
+}
+
 // BeginningOfMonth returns a new instance of Time representing the first day of the month of t.
 // The time is reset to 00:00:00
 func (t Time) BeginningOfMonth() Time {
 	return Date(t.year, t.month, 1, 0, 0, 0, 0, t.loc)
 }
 
+// EndOfMonth returns a new instance of Time representing the last day of the month of t.
+// The time is set to 23:59:59.999999999
+func (t Time) EndOfMonth() Time {
+	return Date(t.year, t.month, monthLength(t.year, t.month), 23, 59, 59, 999999999, t.loc)
+}
+
+// MonthsUntil returns the first day of each month from the month of t through the month of end, inclusive.
+// The time of each day is reset to 00:00:00 in the location of t.
+//
+// It returns nil if the month of end is before the month of t.
+func (t Time) MonthsUntil(end Time) []Time {
+	n := (end.year-t.year)*12 + int(end.month-t.month) + 1
+	if n <= 0 {
+		return nil
+	}
+
+	months := make([]Time, n)
+	for i := range months {
+		months[i] = Date(t.year, t.month+Month(i), 1, 0, 0, 0, 0, t.loc)
+	}
+	return months
+}
+
 // FirstMonthDay returns a new instance of Time representing the first day of the month of t.
 func (t Time) FirstMonthDay() Time {
 	if t.day == 1 {
@@ -664,12 +1250,68 @@ func (t Time) LastMonthDay() Time {
 	return Date(t.year, t.month, ld, t.hour, t.min, t.sec, t.nsec, t.loc)
 }
 
+// Quarter returns the quarter of the year of t in the range [1, 4], i.e. the season of t.
+func (t Time) Quarter() int {
+	return (int(t.month)-1)/3 + 1
+}
+
+// BeginningOfQuarter returns a new instance of Time representing the first day of the quarter of t.
+// The time is reset to 00:00:00
+func (t Time) BeginningOfQuarter() Time {
+	return Date(t.year, Month(3*t.Quarter()-2), 1, 0, 0, 0, 0, t.loc)
+}
+
+// EndOfQuarter returns a new instance of Time representing the last day of the quarter of t.
+// The time is set to 23:59:59.999999999
+func (t Time) EndOfQuarter() Time {
+	m := Month(3 * t.Quarter())
+	return Date(t.year, m, monthLength(t.year, m), 23, 59, 59, 999999999, t.loc)
+}
+
 // BeginningOfYear returns a new instance of Time representing the first day of the year of t.
 // The time is reset to 00:00:00
 func (t Time) BeginningOfYear() Time {
 	return Date(t.year, Farvardin, 1, 0, 0, 0, 0, t.loc)
 }
 
+// EndOfYear returns a new instance of Time representing the last day of the year of t.
+// The time is set to 23:59:59.999999999
+func (t Time) EndOfYear() Time {
+	return Date(t.year, Esfand, monthLength(t.year, Esfand), 23, 59, 59, 999999999, t.loc)
+}
+
+// FiscalYear returns the fiscal year of t for the fiscal years which start on the day of
+// startMonth. The fiscal year is labeled by the year in which it starts, e.g. 1403/01/05 is in
+// the fiscal year 1402 if the fiscal years start on Mehr 1.
+//
+// If startMonth is 0, the fiscal years start on Farvardin 1, i.e. the fiscal year is the year of t.
+// A fiscal year starting on Esfand 30 starts on Esfand 29 in the non-leap years.
+// It panics if startMonth or startDay is out of range.
+func (t Time) FiscalYear(startMonth Month, startDay int) int {
+	if startMonth == 0 {
+		startMonth, startDay = Farvardin, 1
+	}
+
+	year := t.year
+	if d := occurrenceDay(year, startMonth, startDay, "FiscalYear"); t.month < startMonth || (t.month == startMonth && t.day < d) {
+		year--
+	}
+	return year
+}
+
+// FiscalYearBounds returns the first day at 00:00:00 and the last day at 23:59:59.999999999
+// of the fiscal year of t in the location of t. See FiscalYear for the arguments.
+func (t Time) FiscalYearBounds(startMonth Month, startDay int) (start, end Time) {
+	if startMonth == 0 {
+		startMonth, startDay = Farvardin, 1
+	}
+
+	year := t.FiscalYear(startMonth, startDay)
+	start = Date(year, startMonth, occurrenceDay(year, startMonth, startDay, "FiscalYearBounds"), 0, 0, 0, 0, t.loc)
+	next := Date(year+1, startMonth, occurrenceDay(year+1, startMonth, startDay, "FiscalYearBounds"), 0, 0, 0, 0, t.loc)
+	return start, next.AddDate(0, 0, -1).EndOfDay()
+}
+
 // FirstYearDay returns a new instance of Time representing the first day of the year of t.
 func (t Time) FirstYearDay() Time {
 	if t.month == Farvardin && t.day == 1 {
@@ -691,19 +1333,146 @@ func (t Time) LastYearDay() Time {
 	return Date(t.year, Esfand, ld, t.hour, t.min, t.sec, t.nsec, t.loc)
 }
 
-// MonthWeek returns the week of month of t.
+// MonthWeek returns the week of month of t in the range [1, 6].
+//
+// Weeks start on Shanbeh and the first week of the month is the week
+// which contains its first day, so the first day of a month is always in week 1.
 func (t Time) MonthWeek() int {
 	return int(math.Ceil(float64(t.day+int(t.FirstMonthDay().Weekday())) / 7.0))
 }
 
-// YearWeek returns the week of year of t.
+// MonthWeekFrom is like MonthWeek but the weeks start on the weekday start.
+func (t Time) MonthWeekFrom(start Weekday) int {
+	return (t.day-1+divider(int(t.FirstMonthDay().wday-start), 7))/7 + 1
+}
+
+// RMonthWeek returns the number of remaining weeks of the month of t.
+func (t Time) RMonthWeek() int {
+	return t.LastMonthDay().MonthWeek() - t.MonthWeek()
+}
+
+// YearWeek returns the week of year of t in the range [1, 54].
+//
+// Weeks start on Shanbeh and the first week of the year is the week
+// which contains Farvardin 1, so the first day of a year is always in week 1.
+// The first and the last weeks of a year may have less than 7 days, since
+// unlike the ISO week numbering the weeks are never shared between two years.
 func (t Time) YearWeek() int {
 	return int(math.Ceil(float64(t.YearDay()+int(t.FirstYearDay().Weekday())) / 7.0))
 }
 
+// PersianWeekNumber returns the week number of t as numbered in the Iranian calendars.
+// It is an alias of YearWeek.
+//
+// The weeks run from Shanbeh to Jomeh. Week 1 is the week which contains Farvardin 1,
+// i.e. it starts on the Shanbeh on or before Farvardin 1 and it may have less than 7 days
+// in the year. Week 2 starts on the first Shanbeh after Farvardin 1 and so on. The last
+// week of the year ends on the last day of Esfand and it is not counted as a week of the
+// next year, so the result is in the range [1, 54]. This is the rule of
+// CalendarWeekRule.FirstDay with DayOfWeek.Saturday in Calendar.GetWeekOfYear of .NET
+// (e.g. for its PersianCalendar).
+func (t Time) PersianWeekNumber() int {
+	return t.YearWeek()
+}
+
+// WeekYear returns the week-numbering year and the week number of t in the ISO-like week
+// numbering of the Persian calendar.
+//
+// The weeks run from Shanbeh to Jomeh and each week belongs to the year which contains most
+// of its days, i.e. the year of its Seshanbeh. So week 1 is the week of the first Seshanbeh of
+// the year, the days of Esfand may belong to week 1 of the next year and the days of Farvardin
+// may belong to the last week (52 or 53) of the previous year. Unlike YearWeek,
+// every week has 7 days.
+func (t Time) WeekYear() (year, week int) {
+	seshanbeh := t.jdn() - int(t.wday) + int(Seshanbeh)
+	year, _, _ = getDate(seshanbeh)
+	return year, (seshanbeh-nowruzJdn(year))/7 + 1
+}
+
+// YearWeekFrom is like YearWeek but the weeks start on the weekday start.
+func (t Time) YearWeekFrom(start Weekday) int {
+	return (t.YearDay()-1+divider(int(t.FirstYearDay().wday-start), 7))/7 + 1
+}
+
 // RYearWeek returns the number of remaining weeks of the year of t.
 func (t Time) RYearWeek() int {
-	return 52 - t.YearWeek()
+	return t.LastYearDay().YearWeek() - t.YearWeek()
+}
+
+// RoundToDay returns a new instance of Time representing the beginning of the nearest day of t.
+//
+// Before 12:00:00 it returns the day of t and from 12:00:00 the next day, at 00:00:00 in the location of t.
+func (t Time) RoundToDay() Time {
+	if t.hour < 12 {
+		return t.BeginningOfDay()
+	}
+	return Date(t.year, t.month, t.day+1, 0, 0, 0, 0, t.loc)
+}
+
+// RoundToWeek returns a new instance of Time representing the beginning of the nearest week of t,
+// i.e. Shanbeh at 00:00:00 in the location of t.
+//
+// Before the midpoint of the week, 3.5 days after its beginning (Seshanbeh 12:00:00),
+// it returns the first day of the week of t and from the midpoint the first day of the next week.
+func (t Time) RoundToWeek() Time {
+	return t.RoundToWeekFrom(Shanbeh)
+}
+
+// RoundToWeekFrom is like RoundToWeek but the weeks start on the weekday start,
+// so the midpoint is 12:00:00 on the fourth day of the week.
+func (t Time) RoundToWeekFrom(start Weekday) Time {
+	d := divider(int(t.wday-start), 7)
+	if d > 3 || (d == 3 && t.hour >= 12) {
+		d -= 7
+	}
+	return Date(t.year, t.month, t.day-d, 0, 0, 0, 0, t.loc)
+}
+
+// RoundToHour returns a new instance of Time representing the beginning of the nearest hour of t.
+//
+// Before 30 minutes it returns the hour of t and from 30 minutes the next hour.
+func (t Time) RoundToHour() Time {
+	if t.min < 30 {
+		return Date(t.year, t.month, t.day, t.hour, 0, 0, 0, t.loc)
+	}
+	return Date(t.year, t.month, t.day, t.hour+1, 0, 0, 0, t.loc)
+}
+
+// UntilEndOfDay returns the duration from t to the last nanosecond of the day of t.
+func (t Time) UntilEndOfDay() time.Duration {
+	return t.EndOfDay().Time().Sub(t.Time())
+}
+
+// UntilEndOfMonth returns the duration from t to the last nanosecond of the month of t.
+func (t Time) UntilEndOfMonth() time.Duration {
+	return t.EndOfMonth().Time().Sub(t.Time())
+}
+
+// UntilEndOfYear returns the duration from t to the last nanosecond of the year of t.
+func (t Time) UntilEndOfYear() time.Duration {
+	return t.EndOfYear().Time().Sub(t.Time())
+}
+
+// YearProgress returns the elapsed fraction of the year of t between 0 and 1,
+// i.e. the elapsed time since the beginning of the year divided by the length of the year.
+func (t Time) YearProgress() float64 {
+	return progress(t, t.BeginningOfYear(), Date(t.year+1, Farvardin, 1, 0, 0, 0, 0, t.loc))
+}
+
+// MonthProgress returns the elapsed fraction of the month of t between 0 and 1.
+func (t Time) MonthProgress() float64 {
+	return progress(t, t.BeginningOfMonth(), Date(t.year, t.month+1, 1, 0, 0, 0, 0, t.loc))
+}
+
+// DayProgress returns the elapsed fraction of the day of t between 0 and 1.
+func (t Time) DayProgress() float64 {
+	return progress(t, t.BeginningOfDay(), Date(t.year, t.month, t.day+1, 0, 0, 0, 0, t.loc))
+}
+
+// progress returns the elapsed fraction of the period [begin, end) at t.
+func progress(t, begin, end Time) float64 {
+	b := begin.Time()
+	return float64(t.Time().Sub(b)) / float64(end.Time().Sub(b))
 }
 
 // Yesterday returns a new instance of Time representing a day before the day of t.
@@ -716,6 +1485,48 @@ func (t Time) Tomorrow() Time {
 	return t.AddDate(0, 0, 1)
 }
 
+// AddWeeks returns a new instance of Time for t+n weeks.
+func (t Time) AddWeeks(n int) Time {
+	return t.AddDate(0, 0, 7*n)
+}
+
+// NextOccurrence returns the first Time at or after t which falls on the day of the month,
+// keeping the clock and the location of t.
+//
+// Esfand 30 occurs on Esfand 29 in the non-leap years.
+// It panics if month or day is out of range.
+func (t Time) NextOccurrence(month Month, day int) Time {
+	year := t.year
+	if d := occurrenceDay(year, month, day, "NextOccurrence"); month < t.month || (month == t.month && d < t.day) {
+		year++
+	}
+	return Date(year, month, occurrenceDay(year, month, day, "NextOccurrence"), t.hour, t.min, t.sec, t.nsec, t.loc)
+}
+
+// PrevOccurrence returns the last Time at or before t which falls on the day of the month,
+// keeping the clock and the location of t.
+//
+// Esfand 30 occurs on Esfand 29 in the non-leap years.
+// It panics if month or day is out of range.
+func (t Time) PrevOccurrence(month Month, day int) Time {
+	year := t.year
+	if d := occurrenceDay(year, month, day, "PrevOccurrence"); month > t.month || (month == t.month && d > t.day) {
+		year--
+	}
+	return Date(year, month, occurrenceDay(year, month, day, "PrevOccurrence"), t.hour, t.min, t.sec, t.nsec, t.loc)
+}
+
+// occurrenceDay returns day clamped to the number of days of the month of the year.
+func occurrenceDay(year int, month Month, day int, fn string) int {
+	if month < Farvardin || month > Esfand || day < 1 || day > month.Length(true) {
+		panic("ptime: month or day out of range in call to " + fn)
+	}
+	if n := monthLength(year, month); day > n {
+		return n
+	}
+	return day
+}
+
 // Add returns a new instance of Time for t+d.
 func (t Time) Add(d time.Duration) Time {
 	return New(t.Time().Add(d))
@@ -727,11 +1538,198 @@ func (t Time) AddDate(years, months, days int) Time {
 	return t
 }
 
+// AddMonthsMode returns a new instance of Time for t.month+n, where mode specifies
+// how a day which does not exist in the resulting month is handled, e.g.
+//
+//	Farvardin 31 + 1 month is Ordibehesht 31 in both modes
+//	Shahrivar 31 + 1 month is Mehr 30 with ClampDay and Aban 1 with RollOver
+//	Esfand 30, 1403 + 12 months is Esfand 29, 1404 with ClampDay and Farvardin 1, 1405 with RollOver
+func (t Time) AddMonthsMode(n int, mode OverflowMode) Time {
+	if mode == RollOver {
+		return t.AddDate(0, n, 0)
+	}
+	return t.addMonthsClamp(n)
+}
+
+// MonthlyRecurrence returns n occurrences of the day of t in successive months, starting
+// with t itself, keeping the clock and the location of t. The day is clamped to the last day
+// of the months which are shorter, e.g. day 31 falls on Mehr 30 and Esfand 29 or 30,
+// and each occurrence is computed from t so the later months are not affected by the clamping.
+//
+// It returns nil if n is not positive.
+func (t Time) MonthlyRecurrence(n int) []Time {
+	if n <= 0 {
+		return nil
+	}
+
+	times := make([]Time, n)
+	for i := range times {
+		times[i] = t.addMonthsClamp(i)
+	}
+	return times
+}
+
 // Since returns the number of seconds between t and t2.
 func (t Time) Since(t2 Time) int64 {
 	return int64(math.Abs(float64(t2.Unix() - t.Unix())))
 }
 
+// EqualApprox reports whether t and u represent instants which are at most tolerance apart.
+func (t Time) EqualApprox(u Time, tolerance time.Duration) bool {
+	d := t.Time().Sub(u.Time())
+	if d < 0 {
+		d = -d
+	}
+	return d >= 0 && d <= tolerance
+}
+
+// IsPast reports whether t is before the instant now.
+func (t Time) IsPast(now Time) bool {
+	return t.Time().Before(now.Time())
+}
+
+// IsFuture reports whether t is after the instant now.
+func (t Time) IsFuture(now Time) bool {
+	return t.Time().After(now.Time())
+}
+
+// IsToday reports whether t is in the same day as the instant now in the location of t.
+func (t Time) IsToday(now Time) bool {
+	n := New(now.Time().In(t.Time().Location()))
+	return n.year == t.year && n.month == t.month && n.day == t.day
+}
+
+// NowruzWeekday returns the weekday of the first day of the year (Farvardin 1).
+//
+// loc is a pointer to time.Location and must not be nil. The weekday of
+// a day does not depend on the location, and loc is only validated.
+func NowruzWeekday(year int, loc *time.Location) Weekday {
+	if loc == nil {
+		panic("ptime: the Location must not be nil in call to NowruzWeekday")
+	}
+
+	return jdnWeekday(getJdn(year, 1, 1))
+}
+
+// NextNowruz returns the first beginning of a year (Farvardin 1 at 00:00:00) at or after
+// the instant of from, in the location of from.
+func NextNowruz(from Time) Time {
+	if from.month == Farvardin && from.day == 1 && from.hour == 0 && from.min == 0 && from.sec == 0 && from.nsec == 0 {
+		return from
+	}
+	return Date(from.year+1, Farvardin, 1, 0, 0, 0, 0, from.loc)
+}
+
+// PreviousNowruz returns the last beginning of a year (Farvardin 1 at 00:00:00) at or before
+// the instant of from, in the location of from, e.g. for the number of days since Nowruz.
+func PreviousNowruz(from Time) Time {
+	return Date(from.year, Farvardin, 1, 0, 0, 0, 0, from.loc)
+}
+
+// WeeksBetween returns the number of complete 7-day weeks between the instants of t and u.
+// A week is 7*24 hours of elapsed time, regardless of daylight saving time transitions.
+//
+// The result is positive if u is after t and negative if u is before t.
+func (t Time) WeeksBetween(u Time) int {
+	return t.DiffIn(UnitWeek, u)
+}
+
+// DiffIn returns the difference from t to u in whole units of unit, truncated toward zero.
+// The result is positive if u is after t and negative if u is before t.
+//
+// UnitYear and UnitMonth are calendar-aware: the result is the largest number of months
+// (or 12-month years) which can be added to t by AddMonthsMode with ClampDay without passing u,
+// where u is converted to the location of t. e.g. from 1403/12/30 to 1404/12/29 is 1 year and
+// from Shahrivar 31 to Mehr 30 is 1 month.
+//
+// The other units are based on the elapsed time, e.g. a day is 24 hours regardless of
+// daylight saving time transitions. See DaysUntil for the number of calendar days.
+//
+// DiffIn panics if unit is not one of the defined units.
+func (t Time) DiffIn(unit Unit, u Time) int {
+	switch unit {
+	case UnitYear, UnitMonth:
+		u = New(u.Time().In(t.Time().Location()))
+		months := (u.year-t.year)*12 + int(u.month-t.month)
+		if months > 0 && t.addMonthsClamp(months).Time().After(u.Time()) {
+			months--
+		} else if months < 0 && t.addMonthsClamp(months).Time().Before(u.Time()) {
+			months++
+		}
+		if unit == UnitYear {
+			return months / 12
+		}
+		return months
+	case UnitWeek:
+		return int(t.elapsedSeconds(u) / (7 * 86400))
+	case UnitDay:
+		return int(t.elapsedSeconds(u) / 86400)
+	case UnitHour:
+		return int(t.elapsedSeconds(u) / 3600)
+	case UnitMinute:
+		return int(t.elapsedSeconds(u) / 60)
+	case UnitSecond:
+		return int(t.elapsedSeconds(u))
+	}
+	panic("ptime: unit out of range in call to DiffIn")
+}
+
+// DiffSigned returns the difference between t and u in calendar years, months and days,
+// where sign is 1 if u is after t, -1 if u is before t and 0 if they are the same instant.
+// u is converted to the location of t.
+//
+// The magnitudes are always non-negative and are counted from the earlier time to the later
+// one, so DiffSigned(u) of t and DiffSigned(t) of u differ only in sign. The whole months are
+// counted as in DiffIn with UnitMonth, e.g. from 1403/12/30 to 1404/12/29 is 1 year, and the
+// remaining whole days are counted by the clock, e.g. from 10:00 to 09:00 of the next day is 0 days.
+func (t Time) DiffSigned(u Time) (sign int, years, months, days int) {
+	a, b := t, New(u.Time().In(t.Time().Location()))
+	switch {
+	case a.Time().Equal(b.Time()):
+		return 0, 0, 0, 0
+	case a.Time().After(b.Time()):
+		sign, a, b = -1, b, a
+	default:
+		sign = 1
+	}
+
+	months = a.DiffIn(UnitMonth, b)
+	rest := a.addMonthsClamp(months)
+	days = b.jdn() - rest.jdn()
+	if b.clockNanoseconds() < rest.clockNanoseconds() {
+		days--
+	}
+	return sign, months / 12, months % 12, days
+}
+
+// clockNanoseconds returns the number of nanoseconds of t since the beginning of its day
+// by the clock of t.
+func (t Time) clockNanoseconds() int64 {
+	return int64(t.hour*3600+t.min*60+t.sec)*1e9 + int64(t.nsec)
+}
+
+// elapsedSeconds returns the number of whole seconds from t to u, truncated toward zero.
+func (t Time) elapsedSeconds(u Time) int64 {
+	sec := u.Unix() - t.Unix()
+	nsec := u.nsec - t.nsec
+	if sec > 0 && nsec < 0 {
+		sec--
+	} else if sec < 0 && nsec > 0 {
+		sec++
+	}
+	return sec
+}
+
+// DaysUntil returns the number of calendar days from the date of t to the date of u,
+// where u is converted to the location of t. The clocks are ignored, i.e. both times are
+// compared at their local midnight, so from 23:00 to 01:00 of the next day is 1 day
+// and the daylight saving time transitions do not affect the result.
+//
+// The result is positive if u is on a later date than t and negative if it is on an earlier date.
+func (t Time) DaysUntil(u Time) int {
+	return New(u.Time().In(t.Time().Location())).jdn() - t.jdn()
+}
+
 // IsLeap returns true if the year of t is a leap year.
 func (t Time) IsLeap() bool {
 	return isLeap(t.year)
@@ -741,6 +1739,14 @@ func isLeap(year int) bool {
 	return divider(25*year+11, 33) < 8
 }
 
+// monthLength returns the number of days in the month of the year.
+func monthLength(year int, month Month) int {
+	if isLeap(year) {
+		return pMonthCount[month-1][1]
+	}
+	return pMonthCount[month-1][0]
+}
+
 // AmPm returns the 12-Hour marker of t.
 func (t Time) AmPm() AmPm {
 	m := Am
@@ -784,6 +1790,7 @@ func (t Time) ZoneOffset(f ...string) string {
 	sign := "+"
 	if offset < 0 {
 		sign = "-"
+		offset = -offset
 	}
 
 	h := offset / 3600
@@ -799,10 +1806,56 @@ func (t Time) ZoneOffset(f ...string) string {
 	}
 }
 
+// DualString returns the date of t in both Persian and Gregorian calendars
+// with Persian digits and names (e.g. ۱۵ فروردین ۱۴۰۳ (۳ آوریل ۲۰۲۴)).
+func (t Time) DualString() string {
+	g := t.Time()
+	return toPersianDigits(fmt.Sprintf("%s (%d %s %d)", t.Format("d MMM yyyy"), g.Day(), GregorianMonthFa(g.Month()), g.Year()))
+}
+
+// FormatDual returns the formatted representation of t in both Persian and Gregorian calendars
+// in the format of "fa (en)".
+//
+// faLayout is used to format t by Format and enLayout is used to format t.Time()
+// by the Format method of time.Time (e.g. FormatDual("d MMM yyyy", "2 January 2006")).
+func (t Time) FormatDual(faLayout, enLayout string) string {
+	return t.Format(faLayout) + " (" + t.Time().Format(enLayout) + ")"
+}
+
+// GregorianMonthFa returns the Persian name of the Gregorian month m (e.g. ژانویه).
+func GregorianMonthFa(m time.Month) string {
+	return gmonths[m-1]
+}
+
+// OrdinalDate returns the ordinal date of t, i.e. the year and the day of year,
+// in the format of yyyy-DDD (e.g. 1394-188).
+func (t Time) OrdinalDate() string {
+	return fmt.Sprintf("%04d-%03d", t.year, t.YearDay())
+}
+
+// DayKey returns the date of t in the format of yyyyMMdd (e.g. 13940702).
+// It is suitable to be used as a key for grouping times by day.
+func (t Time) DayKey() string {
+	return fmt.Sprintf("%04d%02d%02d", t.year, t.month, t.day)
+}
+
+// MonthKey returns the month of t in the format of yyyyMM (e.g. 139407).
+// It is suitable to be used as a key for grouping times by month.
+func (t Time) MonthKey() string {
+	return fmt.Sprintf("%04d%02d", t.year, t.month)
+}
+
+// YearKey returns the year of t in the format of yyyy (e.g. 1394).
+// It is suitable to be used as a key for grouping times by year.
+func (t Time) YearKey() string {
+	return fmt.Sprintf("%04d", t.year)
+}
+
 // Format returns the formatted representation of t.
 //
 //		yyyy, yyy, y     year (e.g. 1394)
 //		yy               2-digits representation of year (e.g. 94)
+//		MMMT             the Latin transliteration of the Persian name of month (e.g. Farvardin)
 //		MMM              the Persian name of month (e.g. فروردین)
 //		MMI              the Dari name of month (e.g. حمل)
 //		MM               2-digits representation of month (e.g. 01)
@@ -812,14 +1865,17 @@ func (t Time) ZoneOffset(f ...string) string {
 //		RW               remaining weeks of month
 //		W                week of month
 //		RD               remaining days of year
+//		DDD              3-digits representation of day of year (e.g. 001)
 //		D                day of year
 //		rd               remaining days of month
 //		dd               2-digits representation of day (e.g. 01)
 //		d                day (e.g. 1)
+//		ET               the Latin transliteration of the Persian name of weekday (e.g. Shanbeh)
 //		E                the Persian name of weekday (e.g. شنبه)
 //		e                the Persian short name of weekday (e.g. ش)
 //		A                the Persian name of 12-Hour marker (e.g. قبل از ظهر)
 //		a                the Persian short name of 12-Hour marker (e.g. ق.ظ)
+//		P                the English short name of 12-Hour marker (e.g. AM)
 //		HH               2-digits representation of hour [00-23]
 //		H                hour [0-23]
 //		kk               2-digits representation of hour [01-24]
@@ -832,50 +1888,135 @@ func (t Time) ZoneOffset(f ...string) string {
 //		m                minute [0-59]
 //		ss               2-digits representation of seconds [00-59]
 //		s                seconds [0-59]
-//		ns               nanoseconds
+//		ns               nanoseconds without padding (e.g. 1000000)
+//		SSS              9-digits representation of nanoseconds (e.g. 001000000)
+//		SS               6-digits representation of microseconds (e.g. 001000)
 //		S                3-digits representation of milliseconds (e.g. 001)
+//		f...             fraction of second with as many digits as the number of f's [1-9] (e.g. ff => 00)
+//		.F               a dot and the fraction of second without trailing zeros (e.g. .001),
+//		                 or nothing if the fraction is zero
 //		z                the name of location
 //		Z                zone offset (e.g. +03:30)
+//		gyyyy            the Gregorian year of t.Time() (e.g. 2015)
+//		gMMM             the English name of the Gregorian month of t.Time() (e.g. September)
+//		gMM              2-digits representation of the Gregorian month of t.Time() (e.g. 09)
+//		gdd              2-digits representation of the Gregorian day of t.Time() (e.g. 24)
+//
+// The tokens P and f... were added after the other tokens, so the letters P and f of
+// an existing layout are no longer copied as literal text (e.g. the f of "of" is
+// replaced by the first digit of the fraction of second).
 func (t Time) Format(format string) string {
-	r := strings.NewReplacer(
-		"yyyy", strconv.Itoa(t.year),
-		"yyy", strconv.Itoa(t.year),
-		"yy", strconv.Itoa(t.year)[2:],
-		"y", strconv.Itoa(t.year),
-		"MMM", t.month.String(),
-		"MMI", t.month.Dari(),
-		"MM", fmt.Sprintf("%02d", t.month),
-		"M", strconv.Itoa(int(t.month)),
-		"rw", strconv.Itoa(t.RYearWeek()),
-		"w", strconv.Itoa(t.YearWeek()),
-		"W", strconv.Itoa(t.MonthWeek()),
-		"RD", strconv.Itoa(t.RYearDay()),
-		"D", strconv.Itoa(t.YearDay()),
-		"rd", strconv.Itoa(t.RMonthDay()),
-		"dd", fmt.Sprintf("%02d", t.day),
-		"d", strconv.Itoa(t.day),
-		"E", t.wday.String(),
-		"e", t.wday.Short(),
-		"A", t.AmPm().String(),
-		"a", t.AmPm().Short(),
-		"HH", fmt.Sprintf("%02d", t.hour),
-		"H", strconv.Itoa(t.hour),
-		"KK", fmt.Sprintf("%02d", t.Hour12()),
-		"K", strconv.Itoa(t.Hour12()),
-		"kk", fmt.Sprintf("%02d", modifyHour(t.hour, 24)),
-		"k", strconv.Itoa(modifyHour(t.hour, 24)),
-		"hh", fmt.Sprintf("%02d", modifyHour(t.Hour12(), 12)),
-		"h", strconv.Itoa(modifyHour(t.Hour12(), 12)),
-		"mm", fmt.Sprintf("%02d", t.min),
-		"m", strconv.Itoa(t.min),
-		"ns", strconv.Itoa(t.nsec),
-		"ss", fmt.Sprintf("%02d", t.sec),
-		"s", strconv.Itoa(t.sec),
-		"S", fmt.Sprintf("%03d", t.nsec/1e6),
-		"z", t.loc.String(),
-		"Z", t.ZoneOffset(),
-	)
-	return r.Replace(format)
+	var b strings.Builder
+	for _, c := range compileLayout(format) {
+		if c.token == "" {
+			b.WriteString(c.literal)
+		} else {
+			b.WriteString(t.formatToken(c.token))
+		}
+	}
+	return b.String()
+}
+
+// formatToken returns the formatted representation of the token tok of t.
+func (t Time) formatToken(tok string) string {
+	switch tok {
+	case "yyyy", "yyy", "y":
+		return strconv.Itoa(t.year)
+	case "yy":
+		return twoDigitYear(t.year)
+	case "MMMT":
+		return t.month.Latin()
+	case "MMM":
+		return t.month.String()
+	case "MMI":
+		return t.month.Dari()
+	case "MM":
+		return fmt.Sprintf("%02d", t.month)
+	case "M":
+		return strconv.Itoa(int(t.month))
+	case "rw":
+		return strconv.Itoa(t.RYearWeek())
+	case "w":
+		return strconv.Itoa(t.YearWeek())
+	case "RW":
+		return strconv.Itoa(t.RMonthWeek())
+	case "W":
+		return strconv.Itoa(t.MonthWeek())
+	case "RD":
+		return strconv.Itoa(t.RYearDay())
+	case "DDD":
+		return fmt.Sprintf("%03d", t.YearDay())
+	case "D":
+		return strconv.Itoa(t.YearDay())
+	case "rd":
+		return strconv.Itoa(t.RMonthDay())
+	case "dd":
+		return fmt.Sprintf("%02d", t.day)
+	case "d":
+		return strconv.Itoa(t.day)
+	case "ET":
+		return t.wday.Latin()
+	case "E":
+		return t.wday.String()
+	case "e":
+		return t.wday.Short()
+	case "A":
+		return t.AmPm().String()
+	case "a":
+		return t.AmPm().Short()
+	case "P":
+		return t.AmPm().EnglishShort()
+	case "HH":
+		return fmt.Sprintf("%02d", t.hour)
+	case "H":
+		return strconv.Itoa(t.hour)
+	case "KK":
+		return fmt.Sprintf("%02d", t.Hour12())
+	case "K":
+		return strconv.Itoa(t.Hour12())
+	case "kk":
+		return fmt.Sprintf("%02d", modifyHour(t.hour, 24))
+	case "k":
+		return strconv.Itoa(modifyHour(t.hour, 24))
+	case "hh":
+		return fmt.Sprintf("%02d", modifyHour(t.Hour12(), 12))
+	case "h":
+		return strconv.Itoa(modifyHour(t.Hour12(), 12))
+	case "mm":
+		return fmt.Sprintf("%02d", t.min)
+	case "m":
+		return strconv.Itoa(t.min)
+	case "ns":
+		return strconv.Itoa(t.nsec)
+	case "ss":
+		return fmt.Sprintf("%02d", t.sec)
+	case "s":
+		return strconv.Itoa(t.sec)
+	case "SSS":
+		return fmt.Sprintf("%09d", t.nsec)
+	case "SS":
+		return fmt.Sprintf("%06d", t.nsec/1e3)
+	case "S":
+		return fmt.Sprintf("%03d", t.nsec/1e6)
+	case ".F":
+		return t.shortFraction()
+	case "z":
+		return t.loc.String()
+	case "Z":
+		return t.ZoneOffset()
+	case "gyyyy":
+		return strconv.Itoa(t.Time().Year())
+	case "gMMM":
+		return t.Time().Month().String()
+	case "gMM":
+		return fmt.Sprintf("%02d", t.Time().Month())
+	case "gdd":
+		return fmt.Sprintf("%02d", t.Time().Day())
+	}
+	if strings.Trim(tok, "f") == "" {
+		return t.fraction(len(tok))
+	}
+	return tok
 }
 
 // TimeFormat format in go lang time package style
@@ -953,7 +2094,7 @@ func (t Time) TimeFormat(format string) string {
 
 	params := []string{
 		"{YYYY}", strconv.Itoa(t.year),
-		"{YY}", strconv.Itoa(t.year)[2:],
+		"{YY}", twoDigitYear(t.year),
 		"{MMMM}", t.locMonthName(),
 		"{MMM}", t.locMonthName(),
 		"{MM}", fmt.Sprintf("%02d", int(t.month)),
@@ -990,6 +2131,20 @@ func (t Time) TimeFormat(format string) string {
 	return r.Replace(formatted)
 }
 
+// fraction returns the first n digits of the 9-digits representation of nanoseconds.
+func (t Time) fraction(n int) string {
+	return fmt.Sprintf("%09d", t.nsec)[:n]
+}
+
+// shortFraction returns a dot followed by the fraction of second of t without trailing zeros,
+// or an empty string if the fraction is zero.
+func (t Time) shortFraction() string {
+	if t.nsec == 0 {
+		return ""
+	}
+	return "." + strings.TrimRight(t.fraction(9), "0")
+}
+
 func (t *Time) locMonthName() string {
 	if t.Location().String() == Afghanistan().String() {
 		return t.month.Dari()
@@ -1034,6 +2189,55 @@ func (t *Time) normDay() {
 	between(&t.day, 1, pMonthCount[t.month-1][i])
 }
 
+// persianReplacer replaces the Arabic letters which look like Persian letters.
+var persianReplacer = strings.NewReplacer(
+	"\u0643", "\u06a9", // Arabic kaf (ك) to Persian kaf (ک)
+	"\u064a", "\u06cc", // Arabic yeh (ي) to Persian yeh (ی)
+	"\u0649", "\u06cc", // Arabic alef maksura (ى) to Persian yeh (ی)
+)
+
+// NormalizePersian returns a copy of s with the Arabic kaf (ك) and yeh (ي and ى) converted
+// to the Persian kaf (ک) and yeh (ی), and the redundant zero-width non-joiners removed,
+// i.e. the repeated ones and the ones at the beginning or the end of a word.
+func NormalizePersian(s string) string {
+	rs := []rune(persianReplacer.Replace(s))
+	out := rs[:0]
+	for i, r := range rs {
+		if r == '\u200c' && (len(out) == 0 || out[len(out)-1] == '\u200c' || unicode.IsSpace(out[len(out)-1]) ||
+			i == len(rs)-1 || rs[i+1] == '\u200c' || unicode.IsSpace(rs[i+1])) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// ToLatinDigits returns a copy of s with all Persian (e.g. ۱۴۰۳) and Arabic-Indic (e.g. ١٤٠٣)
+// digits converted to the ASCII digits.
+func ToLatinDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '۰' && r <= '۹':
+			return r - '۰' + '0'
+		case r >= '٠' && r <= '٩':
+			return r - '٠' + '0'
+		}
+		return r
+	}, s)
+}
+
+// toPersianDigits replaces the ASCII digits of s with Persian digits.
+func toPersianDigits(s string) string {
+	b := make([]rune, 0, len(s))
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			r += '۰' - '0'
+		}
+		b = append(b, r)
+	}
+	return string(b)
+}
+
 func modifyHour(value, max int) int {
 	if value == 0 {
 		value = max
@@ -1058,20 +2262,17 @@ func between(value *int, min, max int) {
 }
 
 func divider(num, den int) int {
-	if num > 0 {
+	if num >= 0 {
 		return num % den
 	}
 	return num - ((((num + 1) / den) - 1) * den)
 }
 
+// getJdn returns the Julian day number of the Persian date.
+//
+// Leap years are determined by the 33-year arithmetic rule of isLeap,
+// so that the conversions are consistent with IsLeap.
 func getJdn(year int, month int, day int) int {
-	base := year - 473
-	if year >= 0 {
-		base--
-	}
-
-	epy := 474 + (base % 2820)
-
 	var md int
 	if month <= 7 {
 		md = (month - 1) * 31
@@ -1079,7 +2280,69 @@ func getJdn(year int, month int, day int) int {
 		md = (month-1)*30 + 6
 	}
 
-	return day + md + (epy*682-110)/2816 + (epy-1)*365 + base/2820*1029983 + 1948320
+	return nowruzJdn(year) + md + day - 1
+}
+
+// nowruzJdn returns the Julian day number of the first day of the year.
+func nowruzJdn(year int) int {
+	return 1948320 + 365*(year-1) + floorDiv(8*year+21, 33)
+}
+
+// getDate returns the Persian year, month and day of the Julian day number jdn.
+func getDate(jdn int) (year, month, day int) {
+	// A 33-year cycle contains 12053 days.
+	year = floorDiv(33*(jdn-1948320)+3, 12053) + 1
+	for jdn < nowruzJdn(year) {
+		year--
+	}
+	for jdn >= nowruzJdn(year+1) {
+		year++
+	}
+
+	dy := jdn - nowruzJdn(year)
+	if dy < 186 {
+		return year, dy/31 + 1, dy%31 + 1
+	}
+
+	dy -= 186
+	return year, dy/30 + 7, dy%30 + 1
+}
+
+// gregorianJdn returns the Julian day number of the date in the proleptic Gregorian calendar.
+func gregorianJdn(year, month, day int) int {
+	a := floorDiv(14-month, 12)
+	y := year + 4800 - a
+	m := month + 12*a - 3
+	return day + floorDiv(153*m+2, 5) + 365*y + floorDiv(y, 4) - floorDiv(y, 100) + floorDiv(y, 400) - 32045
+}
+
+// gregorianDate returns the date in the proleptic Gregorian calendar of the Julian day number jdn.
+func gregorianDate(jdn int) (year, month, day int) {
+	a := jdn + 32044
+	b := floorDiv(4*a+3, 146097)
+	c := a - floorDiv(146097*b, 4)
+	d := floorDiv(4*c+3, 1461)
+	e := c - floorDiv(1461*d, 4)
+	m := floorDiv(5*e+2, 153)
+
+	day = e - floorDiv(153*m+2, 5) + 1
+	month = m + 3 - 12*floorDiv(m, 10)
+	year = 100*b + d - 4800 + floorDiv(m, 10)
+	return
+}
+
+// floorDiv returns the quotient of num and den rounded towards negative infinity.
+func floorDiv(num, den int) int {
+	q := num / den
+	if num%den != 0 && (num < 0) != (den < 0) {
+		q--
+	}
+	return q
+}
+
+// jdnWeekday returns the weekday of the Julian day number jdn.
+func jdnWeekday(jdn int) Weekday {
+	return Weekday(divider(jdn+2, 7))
 }
 
 func getWeekday(wd time.Weekday) Weekday {
@@ -1102,6 +2365,18 @@ func getWeekday(wd time.Weekday) Weekday {
 	return 0
 }
 
+func (t Time) jdn() int {
+	return getJdn(t.year, int(t.month), t.day)
+}
+
 func (t *Time) resetWeekday() {
 	t.wday = getWeekday(t.Time().Weekday())
 }
+
+// twoDigitYear returns the last two digits of year, prefixed by a minus sign if year is negative.
+func twoDigitYear(year int) string {
+	if year < 0 {
+		return fmt.Sprintf("-%02d", -year%100)
+	}
+	return fmt.Sprintf("%02d", year%100)
+}
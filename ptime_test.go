@@ -2,6 +2,10 @@ package ptime_test
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -117,6 +121,18 @@ var dateConversions = []dateConversion{
 		persian:   pdate{1395, Dey, 11},
 		gregorian: gdate{2016, time.December, 31},
 	},
+	{
+		persian:   pdate{1403, Esfand, 30},
+		gregorian: gdate{2025, time.March, 20},
+	},
+	{
+		persian:   pdate{1404, Farvardin, 1},
+		gregorian: gdate{2025, time.March, 21},
+	},
+	{
+		persian:   pdate{1407, Farvardin, 1},
+		gregorian: gdate{2028, time.March, 20},
+	},
 }
 
 var dayFunctionsSlice = []dayFunctions{
@@ -424,10 +440,10 @@ func TestWeeks(t *testing.T) {
 		)
 	}
 
-	if ti.RYearWeek() != 25 {
+	if ti.RYearWeek() != 26 {
 		t.Error(
 			"For", "RYearWeek()",
-			"expected", 25,
+			"expected", 26,
 			"got", ti.RYearWeek(),
 		)
 	}
@@ -539,4 +555,2810 @@ func TestTimeFormat(t *testing.T) {
 			)
 		}
 	}
+
+	years := map[int]string{
+		5:  "05",
+		50: "50",
+		99: "99",
+		-7: "-07",
+	}
+	for y, v := range years {
+		if s := Date(y, Mehr, 2, 14, 7, 8, 52065090, Iran()).TimeFormat("06"); s != v {
+			t.Error(
+				"For", y,
+				"expected", v,
+				"got", s,
+			)
+		}
+	}
+}
+
+func TestDaysInYear(t *testing.T) {
+	if d := Date(1395, Mehr, 2, 12, 59, 59, 0, Iran()).DaysInYear(); d != 366 {
+		t.Error(
+			"For", "DaysInYear()",
+			"expected", 366,
+			"got", d,
+		)
+	}
+
+	if d := Date(1394, Mehr, 2, 12, 59, 59, 0, Iran()).DaysInYear(); d != 365 {
+		t.Error(
+			"For", "DaysInYear()",
+			"expected", 365,
+			"got", d,
+		)
+	}
+
+	if d := YearLength(1399); d != 366 {
+		t.Error(
+			"For", "YearLength(1399)",
+			"expected", 366,
+			"got", d,
+		)
+	}
+}
+
+func TestMonthsUntil(t *testing.T) {
+	ti := Date(1394, Bahman, 20, 12, 59, 59, 0, Iran())
+
+	months := ti.MonthsUntil(Date(1395, Ordibehesht, 3, 8, 0, 0, 0, Iran()))
+	expected := []pdate{
+		{1394, Bahman, 1},
+		{1394, Esfand, 1},
+		{1395, Farvardin, 1},
+		{1395, Ordibehesht, 1},
+	}
+
+	if len(months) != len(expected) {
+		t.Fatal(
+			"For", "len(MonthsUntil())",
+			"expected", len(expected),
+			"got", len(months),
+		)
+	}
+
+	for i, m := range months {
+		p := expected[i]
+		if m.Year() != p.year || m.Month() != p.month || m.Day() != p.day || m.Hour() != 0 {
+			t.Error(
+				"For", fmt.Sprintf("MonthsUntil()[%d]", i),
+				"expected", fmt.Sprintf("%d %s %d 00", p.year, p.month, p.day),
+				"got", fmt.Sprintf("%d %s %d %02d", m.Year(), m.Month(), m.Day(), m.Hour()),
+			)
+		}
+	}
+
+	if n := len(ti.MonthsUntil(ti.LastMonthDay())); n != 1 {
+		t.Error(
+			"For", "len(MonthsUntil()) in the same month",
+			"expected", 1,
+			"got", n,
+		)
+	}
+
+	if n := len(ti.MonthsUntil(ti.AddDate(0, -1, 0))); n != 0 {
+		t.Error(
+			"For", "len(MonthsUntil()) of a previous month",
+			"expected", 0,
+			"got", n,
+		)
+	}
+}
+
+func TestAmPmEnglishShortName(t *testing.T) {
+	if Am.EnglishShort() != "AM" || Pm.EnglishShort() != "PM" {
+		t.Error(
+			"Expected", "AM PM",
+			"got", Am.EnglishShort(), Pm.EnglishShort(),
+		)
+	}
+
+	ti := Date(1394, Mehr, 2, 14, 7, 8, 0, Iran())
+	if s := ti.Format("hh:mm P a"); s != "02:07 PM ب.ظ" {
+		t.Error(
+			"Expected", "02:07 PM ب.ظ",
+			"got", s,
+		)
+	}
+}
+
+func TestSetAmPmNames(t *testing.T) {
+	SetAmPmNames("صبح", "عصر", "ص", "ع")
+	defer SetAmPmNames("قبل از ظهر", "بعد از ظهر", "ق.ظ", "ب.ظ")
+
+	ti := Date(1394, Mehr, 2, 9, 7, 8, 0, Iran())
+	if s := ti.Format("A a P"); s != "صبح ص AM" {
+		t.Error(
+			"Expected", "صبح ص AM",
+			"got", s,
+		)
+	}
+
+	if Pm.String() != "عصر" || Pm.Short() != "ع" {
+		t.Error(
+			"Expected", "عصر ع",
+			"got", Pm.String(), Pm.Short(),
+		)
+	}
+}
+
+func TestEqualApprox(t *testing.T) {
+	ti := Date(1394, Mehr, 2, 12, 59, 59, 500000000, Iran())
+	u := Date(1394, Mehr, 2, 9, 29, 59, 0, time.UTC)
+
+	vals := map[time.Duration]bool{
+		0:                       false,
+		499 * time.Millisecond:  false,
+		500 * time.Millisecond:  true,
+		time.Second:             true,
+		-500 * time.Millisecond: false,
+	}
+	for tol, expected := range vals {
+		if ti.EqualApprox(u, tol) != expected {
+			t.Error(
+				"For", fmt.Sprintf("EqualApprox(u, %s)", tol),
+				"expected", expected,
+				"got", !expected,
+			)
+		}
+		if u.EqualApprox(ti, tol) != expected {
+			t.Error(
+				"For", fmt.Sprintf("u.EqualApprox(t, %s)", tol),
+				"expected", expected,
+				"got", !expected,
+			)
+		}
+	}
+}
+
+func TestGregorianWallClock(t *testing.T) {
+	for ti := Date(1395, Farvardin, 1, 23, 59, 59, 999999999, Iran()); ti.Year() == 1395; ti = ti.Tomorrow() {
+		g := ti.GregorianWallClock()
+		if g.Hour() != ti.Hour() || g.Minute() != ti.Minute() || g.Second() != ti.Second() || g.Nanosecond() != ti.Nanosecond() || g.Location() != ti.Location() {
+			t.Error(
+				"For", ti.String(),
+				"expected", "the same wall clock",
+				"got", g.String(),
+			)
+		}
+
+		if p := FromGregorianWallClock(g); p.String() != ti.String() || !p.Time().Equal(g) {
+			t.Error(
+				"For", g.String(),
+				"expected", ti.String(),
+				"got", p.String(),
+			)
+		}
+	}
+}
+
+func TestFromGregorian(t *testing.T) {
+	for _, p := range dateConversions {
+		pt := FromGregorian(p.gregorian.year, int(p.gregorian.month), p.gregorian.day, 11, 59, 59, 0, Iran())
+
+		if pt.Year() != p.persian.year || pt.Month() != p.persian.month || pt.Day() != p.persian.day || pt.Hour() != 11 {
+			t.Error(
+				"For", fmt.Sprintf("%d %s %d", p.gregorian.year, p.gregorian.month.String(), p.gregorian.day),
+				"expected", fmt.Sprintf("%d %s %d", p.persian.year, p.persian.month.String(), p.persian.day),
+				"got", fmt.Sprintf("%d %s %d", pt.Year(), pt.Month().String(), pt.Day()),
+			)
+		}
+	}
+
+	invalid := [][3]int{
+		{2016, 13, 1},
+		{2016, 0, 1},
+		{2015, 2, 29},
+		{2016, 4, 31},
+	}
+	for _, d := range invalid {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error(
+						"For", fmt.Sprintf("FromGregorian(%d, %d, %d)", d[0], d[1], d[2]),
+						"expected", "panic",
+						"got", nil,
+					)
+				}
+			}()
+			FromGregorian(d[0], d[1], d[2], 0, 0, 0, 0, Iran())
+		}()
+	}
+}
+
+func TestFormatTwoDigitYear(t *testing.T) {
+	years := map[int]string{
+		5:    "05",
+		50:   "50",
+		99:   "99",
+		100:  "00",
+		1403: "03",
+		-7:   "-07",
+	}
+
+	for year, expected := range years {
+		ti := Date(year, Mehr, 2, 12, 0, 0, 0, Iran())
+		if s := ti.Format("yy"); s != expected {
+			t.Error(
+				"For", year,
+				"expected", expected,
+				"got", s,
+			)
+		}
+	}
+}
+
+func TestFormatFraction(t *testing.T) {
+	vals := map[int]map[string]string{
+		0: {
+			"ns":   "0",
+			"S":    "000",
+			"SS":   "000000",
+			"SSS":  "000000000",
+			"f":    "0",
+			"ss.F": "59",
+		},
+		1: {
+			"ns":        "1",
+			".F":        ".000000001",
+			"S":         "000",
+			"SS":        "000000",
+			"SSS":       "000000001",
+			"fff":       "000",
+			"fffffffff": "000000001",
+		},
+		1000000: {
+			"ns":  "1000000",
+			"S":   "001",
+			"SS":  "001000",
+			"SSS": "001000000",
+			".F":  ".001",
+		},
+		50260050: {
+			".F":        ".05026005",
+			"S":         "050",
+			"SS":        "050260",
+			"SSS":       "050260050",
+			"f":         "0",
+			"ff":        "05",
+			"ffff":      "0502",
+			"ffffffff":  "05026005",
+			"fffffffff": "050260050",
+		},
+		123456789: {
+			"ns":  "123456789",
+			"S":   "123",
+			"SS":  "123456",
+			"SSS": "123456789",
+			".F":  ".123456789",
+		},
+		999999999: {
+			"S":   "999",
+			"SS":  "999999",
+			"SSS": "999999999",
+			"f":   "9",
+		},
+	}
+
+	for nsec, layouts := range vals {
+		ti := Date(1394, Mehr, 2, 12, 59, 59, nsec, Iran())
+		for layout, expected := range layouts {
+			if s := ti.Format(layout); s != expected {
+				t.Error(
+					"For", fmt.Sprintf("%d => %s", nsec, layout),
+					"expected", expected,
+					"got", s,
+				)
+			}
+		}
+	}
+}
+
+func TestSetNowFunc(t *testing.T) {
+	frozen := time.Date(2016, time.March, 20, 10, 30, 0, 0, time.UTC)
+	SetNowFunc(func() time.Time { return frozen })
+	defer SetNowFunc(nil)
+
+	now := Now(Iran())
+	if now.Year() != 1395 || now.Month() != Farvardin || now.Day() != 1 || now.Hour() != 14 || now.Minute() != 0 {
+		t.Error(
+			"For", "Now(Iran())",
+			"expected", "1395-01-01T14:00:00",
+			"got", now.String(),
+		)
+	}
+
+	if !now.Time().Equal(frozen) {
+		t.Error(
+			"For", "Now(Iran()).Time()",
+			"expected", frozen,
+			"got", now.Time(),
+		)
+	}
+}
+
+func TestSetDefaultLocation(t *testing.T) {
+	if loc := DefaultLocation(); loc.String() != "Asia/Tehran" {
+		t.Error(
+			"For", "DefaultLocation()",
+			"expected", "Asia/Tehran",
+			"got", loc,
+		)
+	}
+
+	SetDefaultLocation(Afghanistan())
+	defer SetDefaultLocation(nil)
+
+	p, err := Parse("yyyy/MM/dd HH:mm", "1403/12/30 10:00")
+	if err != nil || p.Location().String() != "Asia/Kabul" || p.String() != "1403-12-30T10:00:00+04:30" {
+		t.Error(
+			"For", "Parse in Asia/Kabul",
+			"expected", "1403-12-30T10:00:00+04:30",
+			"got", p.String(), err,
+		)
+	}
+
+	if p, err := ParseRFC3339("1403-12-30T10:00:00+04:30"); err != nil || p.Location().String() != "Asia/Kabul" {
+		t.Error(
+			"For", "ParseRFC3339 in Asia/Kabul",
+			"expected", "Asia/Kabul",
+			"got", p.Location(), err,
+		)
+	}
+
+	var s Time
+	if err := s.Scan("1403/12/30 10:00"); err != nil || s.String() != "1403-12-30T10:00:00+04:30" {
+		t.Error(
+			"For", "Scan in Asia/Kabul",
+			"expected", "1403-12-30T10:00:00+04:30",
+			"got", s.String(), err,
+		)
+	}
+
+	SetDefaultLocation(nil)
+	if p, _ := Parse("yyyy/MM/dd HH:mm", "1403/12/30 10:00"); p.String() != "1403-12-30T10:00:00+03:30" {
+		t.Error(
+			"For", "Parse after SetDefaultLocation(nil)",
+			"expected", "1403-12-30T10:00:00+03:30",
+			"got", p.String(),
+		)
+	}
+}
+
+func TestUntilEnd(t *testing.T) {
+	ti := Date(1395, Esfand, 30, 23, 59, 59, 0, Iran())
+
+	if d := ti.UntilEndOfDay(); d != time.Second-1 {
+		t.Error(
+			"For", "UntilEndOfDay()",
+			"expected", time.Second-1,
+			"got", d,
+		)
+	}
+
+	if d := ti.UntilEndOfMonth(); d != time.Second-1 {
+		t.Error(
+			"For", "UntilEndOfMonth()",
+			"expected", time.Second-1,
+			"got", d,
+		)
+	}
+
+	if d := ti.UntilEndOfYear(); d != time.Second-1 {
+		t.Error(
+			"For", "UntilEndOfYear()",
+			"expected", time.Second-1,
+			"got", d,
+		)
+	}
+
+	ti = Date(1394, Esfand, 28, 0, 0, 0, 0, Iran())
+	if d := ti.UntilEndOfMonth(); d != 48*time.Hour-1 {
+		t.Error(
+			"For", "UntilEndOfMonth() in a non-leap Esfand",
+			"expected", 48*time.Hour-1,
+			"got", d,
+		)
+	}
+
+	if d := ti.UntilEndOfYear(); d != 48*time.Hour-1 {
+		t.Error(
+			"For", "UntilEndOfYear() in a non-leap year",
+			"expected", 48*time.Hour-1,
+			"got", d,
+		)
+	}
+
+	ti = Date(1395, Esfand, 29, 0, 0, 0, 0, Iran())
+	if d := ti.UntilEndOfYear(); d != 48*time.Hour-1 {
+		t.Error(
+			"For", "UntilEndOfYear() in a leap year",
+			"expected", 48*time.Hour-1,
+			"got", d,
+		)
+	}
+
+	ti = Date(1394, Mehr, 2, 0, 0, 0, 0, Iran())
+	if d := ti.UntilEndOfDay(); d != 24*time.Hour-1 {
+		t.Error(
+			"For", "UntilEndOfDay() at midnight",
+			"expected", 24*time.Hour-1,
+			"got", d,
+		)
+	}
+}
+
+func TestZeroTime(t *testing.T) {
+	var ti Time
+
+	if loc := ti.Time().Location(); loc != time.UTC {
+		t.Error(
+			"For", "Time{}.Time().Location()",
+			"expected", time.UTC,
+			"got", loc,
+		)
+	}
+}
+
+func TestTimeInLocation(t *testing.T) {
+	ti := Date(1394, Mehr, 2, 12, 59, 59, 0, Iran())
+
+	g := ti.TimeInLocation(time.UTC)
+	if g.Location() != time.UTC || g.Hour() != 9 || g.Minute() != 29 || !g.Equal(ti.Time()) {
+		t.Error(
+			"For", "TimeInLocation(time.UTC)",
+			"expected", "2015-09-24 09:29:59 +0000 UTC",
+			"got", g,
+		)
+	}
+}
+
+func TestStdWeekday(t *testing.T) {
+	vals := map[Weekday]time.Weekday{
+		Shanbeh:     time.Saturday,
+		Yekshanbeh:  time.Sunday,
+		Doshanbeh:   time.Monday,
+		Seshanbeh:   time.Tuesday,
+		Charshanbeh: time.Wednesday,
+		Panjshanbeh: time.Thursday,
+		Jomeh:       time.Friday,
+	}
+
+	for d, wd := range vals {
+		if d.ToStdWeekday() != wd {
+			t.Error(
+				"For", d.String()+".ToStdWeekday()",
+				"expected", wd,
+				"got", d.ToStdWeekday(),
+			)
+		}
+
+		if FromStdWeekday(wd) != d {
+			t.Error(
+				"For", "FromStdWeekday("+wd.String()+")",
+				"expected", d.String(),
+				"got", FromStdWeekday(wd).String(),
+			)
+		}
+	}
+}
+
+func TestFromStdWeekdayPanic(t *testing.T) {
+	for _, wd := range []time.Weekday{-1, 7} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error(
+						"For", "FromStdWeekday", int(wd),
+						"expected", "panic",
+						"got", nil,
+					)
+				}
+			}()
+			FromStdWeekday(wd)
+		}()
+	}
+}
+
+func TestWeekdayFullYear(t *testing.T) {
+	// 1403/01/01 is a Charshanbeh, the fifth day of the Persian week.
+	ti := Date(1403, Farvardin, 1, 12, 0, 0, 0, Iran())
+	for i := 0; i < 366+365; i++ {
+		expected := Weekday((int(Charshanbeh) + i) % 7)
+		if wd := ti.Weekday(); wd != expected {
+			t.Error(
+				"For", ti.String(),
+				"expected", expected.String(),
+				"got", wd.String(),
+			)
+		}
+		ti = ti.Tomorrow()
+	}
+	if ti.Year() != 1405 || ti.Month() != Farvardin || ti.Day() != 1 {
+		t.Error(
+			"For", "1403/01/01 + 731 days",
+			"expected", "1405/01/01",
+			"got", ti.String(),
+		)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	t1 := Date(1395, Farvardin, 5, 0, 0, 0, 0, Iran())
+	t2 := Date(1395, Farvardin, 5, 23, 59, 59, 999999999, Iran())
+
+	if t1.DayKey() != "13950105" || t1.DayKey() != t2.DayKey() {
+		t.Error(
+			"For", "DayKey()",
+			"expected", "13950105",
+			"got", t1.DayKey(), t2.DayKey(),
+		)
+	}
+
+	if t1.MonthKey() != "139501" {
+		t.Error(
+			"For", "MonthKey()",
+			"expected", "139501",
+			"got", t1.MonthKey(),
+		)
+	}
+
+	if t1.YearKey() != "1395" {
+		t.Error(
+			"For", "YearKey()",
+			"expected", "1395",
+			"got", t1.YearKey(),
+		)
+	}
+
+	if k := t2.Tomorrow().DayKey(); k != "13950106" {
+		t.Error(
+			"For", "Tomorrow().DayKey()",
+			"expected", "13950106",
+			"got", k,
+		)
+	}
+}
+
+type addDateCase struct {
+	from                pdate
+	years, months, days int
+	to                  pdate
+}
+
+var addDateCases = []addDateCase{
+	{pdate{1396, Farvardin, 1}, 0, 0, -1, pdate{1395, Esfand, 30}},
+	{pdate{1395, Farvardin, 1}, 0, 0, -1, pdate{1394, Esfand, 29}},
+	{pdate{1395, Farvardin, 1}, 0, 0, -40, pdate{1394, Bahman, 20}},
+	{pdate{1395, Farvardin, 1}, 0, 0, -365, pdate{1394, Farvardin, 1}},
+	{pdate{1395, Farvardin, 1}, 0, 0, -366, pdate{1393, Esfand, 29}},
+	{pdate{1395, Farvardin, 1}, 0, -13, 0, pdate{1393, Esfand, 1}},
+	{pdate{1395, Farvardin, 1}, -3, 0, -1, pdate{1391, Esfand, 30}},
+	{pdate{1394, Mehr, 1}, 0, 0, 59, pdate{1394, Aban, 30}},
+	{pdate{1394, Mehr, 1}, 0, 0, 60, pdate{1394, Azar, 1}},
+	{pdate{1394, Esfand, 29}, 0, 0, 366, pdate{1395, Esfand, 30}},
+}
+
+func TestAddDateNegative(t *testing.T) {
+	for _, c := range addDateCases {
+		ti := Date(c.from.year, c.from.month, c.from.day, 10, 0, 0, 0, Iran()).AddDate(c.years, c.months, c.days)
+		if ti.Year() != c.to.year || ti.Month() != c.to.month || ti.Day() != c.to.day || ti.Hour() != 10 {
+			t.Error(
+				"For", fmt.Sprintf("%d %s %d + (%d, %d, %d)", c.from.year, c.from.month, c.from.day, c.years, c.months, c.days),
+				"expected", fmt.Sprintf("%d %s %d 10", c.to.year, c.to.month, c.to.day),
+				"got", fmt.Sprintf("%d %s %d %d", ti.Year(), ti.Month(), ti.Day(), ti.Hour()),
+			)
+		}
+	}
+}
+
+func TestAddMonthsMode(t *testing.T) {
+	vals := []struct {
+		from            pdate
+		n               int
+		clamp, rollOver pdate
+	}{
+		{pdate{1403, Farvardin, 31}, 1, pdate{1403, Ordibehesht, 31}, pdate{1403, Ordibehesht, 31}},
+		{pdate{1403, Shahrivar, 31}, 1, pdate{1403, Mehr, 30}, pdate{1403, Aban, 1}},
+		{pdate{1403, Mordad, 31}, 3, pdate{1403, Aban, 30}, pdate{1403, Azar, 1}},
+		{pdate{1403, Bahman, 30}, 1, pdate{1403, Esfand, 30}, pdate{1403, Esfand, 30}},
+		{pdate{1402, Bahman, 30}, 1, pdate{1402, Esfand, 29}, pdate{1403, Farvardin, 1}},
+		{pdate{1403, Esfand, 30}, 12, pdate{1404, Esfand, 29}, pdate{1405, Farvardin, 1}},
+		{pdate{1403, Mehr, 30}, -1, pdate{1403, Shahrivar, 30}, pdate{1403, Shahrivar, 30}},
+		{pdate{1403, Farvardin, 31}, -1, pdate{1402, Esfand, 29}, pdate{1403, Farvardin, 2}},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.from.year, v.from.month, v.from.day, 10, 0, 0, 0, Iran())
+		for mode, expected := range map[OverflowMode]pdate{ClampDay: v.clamp, RollOver: v.rollOver} {
+			got := ti.AddMonthsMode(v.n, mode)
+			if got.Year() != expected.year || got.Month() != expected.month || got.Day() != expected.day || got.Hour() != 10 {
+				t.Error(
+					"For", fmt.Sprintf("%s + %d months (mode %d)", ti.String(), v.n, mode),
+					"expected", expected,
+					"got", got.String(),
+				)
+			}
+		}
+	}
+}
+
+func TestMonthlyRecurrence(t *testing.T) {
+	ti := Date(1402, Farvardin, 31, 9, 30, 0, 0, Iran())
+	expected := []pdate{
+		{1402, Farvardin, 31}, {1402, Ordibehesht, 31}, {1402, Khordad, 31}, {1402, Tir, 31},
+		{1402, Mordad, 31}, {1402, Shahrivar, 31}, {1402, Mehr, 30}, {1402, Aban, 30},
+		{1402, Azar, 30}, {1402, Dey, 30}, {1402, Bahman, 30}, {1402, Esfand, 29},
+		{1403, Farvardin, 31}, {1403, Ordibehesht, 31},
+	}
+
+	times := ti.MonthlyRecurrence(len(expected))
+	if len(times) != len(expected) {
+		t.Fatal(
+			"For", "MonthlyRecurrence",
+			"expected", len(expected),
+			"got", len(times),
+		)
+	}
+	for i, d := range times {
+		e := expected[i]
+		if d.Year() != e.year || d.Month() != e.month || d.Day() != e.day || d.Hour() != 9 || d.Minute() != 30 {
+			t.Error(
+				"For", i,
+				"expected", e,
+				"got", d.String(),
+			)
+		}
+	}
+
+	if d := Date(1403, Dey, 30, 0, 0, 0, 0, Iran()).MonthlyRecurrence(3)[2]; d.Month() != Esfand || d.Day() != 30 {
+		t.Error(
+			"For", "1403/10/30 + 2 months",
+			"expected", "1403/12/30",
+			"got", d.String(),
+		)
+	}
+	if times := ti.MonthlyRecurrence(0); times != nil {
+		t.Error(
+			"For", "MonthlyRecurrence(0)",
+			"expected", nil,
+			"got", times,
+		)
+	}
+}
+
+func TestAddNegative(t *testing.T) {
+	ti := Date(1396, Farvardin, 1, 0, 30, 0, 0, Iran())
+
+	y := ti.Add(-24 * time.Hour)
+	if y.Year() != 1395 || y.Month() != Esfand || y.Day() != 30 || y.Hour() != 0 || y.Minute() != 30 {
+		t.Error(
+			"For", "Add(-24h)",
+			"expected", "1395 Esfand 30 00:30",
+			"got", y.String(),
+		)
+	}
+
+	y = ti.Add(-(365 + 366 + 365) * 24 * time.Hour)
+	if y.Year() != 1393 || y.Month() != Farvardin || y.Day() != 1 || y.Hour() != 0 || y.Minute() != 30 {
+		t.Error(
+			"For", "Add(-3 years)",
+			"expected", "1393 Farvardin 1 00:30",
+			"got", y.String(),
+		)
+	}
+
+	if y.Add((365+366+365)*24*time.Hour).String() != ti.String() {
+		t.Error(
+			"For", "Add(3 years)",
+			"expected", ti.String(),
+			"got", y.Add((365+366+365)*24*time.Hour).String(),
+		)
+	}
+}
+
+func TestNextPreviousNowruz(t *testing.T) {
+	vals := []struct {
+		from       Time
+		prev, next string
+	}{
+		{Date(1404, Farvardin, 1, 0, 0, 0, 1, Iran()), "2025-03-21T00:00:00+03:30", "2026-03-21T00:00:00+03:30"},
+		{Date(1403, Esfand, 30, 23, 59, 59, 999999999, Iran()), "2024-03-20T00:00:00+03:30", "2025-03-21T00:00:00+03:30"},
+		{Date(1404, Farvardin, 1, 0, 0, 0, 0, Iran()), "2025-03-21T00:00:00+03:30", "2025-03-21T00:00:00+03:30"},
+		{Date(1403, Mehr, 2, 12, 0, 0, 0, Afghanistan()), "2024-03-20T00:00:00+04:30", "2025-03-21T00:00:00+04:30"},
+	}
+
+	for _, v := range vals {
+		if p := PreviousNowruz(v.from); p.Time().Format(time.RFC3339) != v.prev || p.Month() != Farvardin || p.Day() != 1 {
+			t.Error(
+				"For", "PreviousNowruz", v.from.String(),
+				"expected", v.prev,
+				"got", p.Time(),
+			)
+		}
+		if n := NextNowruz(v.from); n.Time().Format(time.RFC3339) != v.next || n.Month() != Farvardin || n.Day() != 1 {
+			t.Error(
+				"For", "NextNowruz", v.from.String(),
+				"expected", v.next,
+				"got", n.Time(),
+			)
+		}
+	}
+}
+
+func TestNowruzWeekday(t *testing.T) {
+	vals := map[int]Weekday{
+		1394: Shanbeh,
+		1395: Yekshanbeh,
+		1396: Seshanbeh,
+		1397: Charshanbeh,
+		1400: Yekshanbeh,
+	}
+
+	for year, wd := range vals {
+		if d := NowruzWeekday(year, Iran()); d != wd {
+			t.Error(
+				"For", fmt.Sprintf("NowruzWeekday(%d)", year),
+				"expected", wd.String(),
+				"got", d.String(),
+			)
+		}
+
+		if d := Date(year, Farvardin, 1, 12, 0, 0, 0, Iran()).Weekday(); d != wd {
+			t.Error(
+				"For", fmt.Sprintf("Date(%d, Farvardin, 1).Weekday()", year),
+				"expected", wd.String(),
+				"got", d.String(),
+			)
+		}
+	}
+}
+
+func TestConversionConsistency(t *testing.T) {
+	g := time.Date(1700, time.January, 1, 12, 0, 0, 0, time.UTC)
+	prev := New(g)
+	for g.Year() < 2300 {
+		g = g.AddDate(0, 0, 1)
+		p := New(g)
+
+		if tomorrow := prev.Tomorrow(); tomorrow.Year() != p.Year() || tomorrow.Month() != p.Month() || tomorrow.Day() != p.Day() {
+			t.Fatal(
+				"For", g,
+				"expected", fmt.Sprintf("%d %d %d", tomorrow.Year(), tomorrow.Month(), tomorrow.Day()),
+				"got", fmt.Sprintf("%d %d %d", p.Year(), p.Month(), p.Day()),
+			)
+		}
+
+		if p.Month() == Farvardin && p.Day() == 1 && prev.Day() != prev.LastYearDay().Day() {
+			t.Fatal(
+				"For", g,
+				"expected", fmt.Sprintf("Esfand %d", prev.LastYearDay().Day()),
+				"got", fmt.Sprintf("Esfand %d", prev.Day()),
+			)
+		}
+
+		if p.Weekday() != FromStdWeekday(g.Weekday()) || !p.Time().Equal(g) {
+			t.Fatal(
+				"For", g,
+				"expected", g,
+				"got", p.Time(),
+			)
+		}
+		prev = p
+	}
+}
+
+func TestSetTimeRandom(t *testing.T) {
+	vals := []time.Time{
+		time.Unix(1454277270, 0).In(Iran()),
+		time.Unix(1742416200, 999999999).In(Iran()),
+		time.Unix(-42521974200, 1).In(Afghanistan()),
+		time.Unix(-60000000000, 0).In(time.UTC),
+		time.Unix(32503680000, 0).In(time.UTC),
+		time.Unix(-12219724800, 0).In(time.UTC),
+	}
+
+	locs := []*time.Location{Iran(), Afghanistan(), time.UTC}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		vals = append(vals, time.Unix(r.Int63n(12e10)-6e10, r.Int63n(1e9)).In(locs[r.Intn(len(locs))]))
+	}
+
+	for _, g := range vals {
+		p := New(g)
+		if !p.Time().Equal(g) {
+			t.Fatal(
+				"For", g,
+				"expected", g,
+				"got", p.Time(),
+			)
+		}
+
+		d := Date(p.Year(), p.Month(), p.Day(), p.Hour(), p.Minute(), p.Second(), p.Nanosecond(), p.Location())
+		if y, m, dd := d.Date(); y != p.Year() || m != p.Month() || dd != p.Day() || !d.Time().Equal(g) {
+			t.Fatal(
+				"For", g,
+				"expected", fmt.Sprintf("%d %d %d", p.Year(), p.Month(), p.Day()),
+				"got", fmt.Sprintf("%d %d %d", y, m, dd),
+			)
+		}
+
+		if p.Day() < 1 || p.Day() > p.LastMonthDay().Day() || p.Month() < Farvardin || p.Month() > Esfand {
+			t.Fatal(
+				"For", g,
+				"expected", "a valid date",
+				"got", fmt.Sprintf("%d %d %d", p.Year(), p.Month(), p.Day()),
+			)
+		}
+	}
+}
+
+func TestIsPastFuture(t *testing.T) {
+	now := Date(1394, Mehr, 2, 12, 0, 0, 0, Iran())
+
+	vals := []struct {
+		t            Time
+		past, future bool
+		today        bool
+		name         string
+	}{
+		{now, false, false, true, "now"},
+		{now.Add(-time.Nanosecond), true, false, true, "now-1ns"},
+		{now.Add(time.Nanosecond), false, true, true, "now+1ns"},
+		{Date(1394, Mehr, 2, 8, 30, 0, 0, time.UTC), false, false, true, "now in UTC"},
+		{Date(1394, Mehr, 1, 23, 59, 59, 999999999, Iran()), true, false, false, "previous midnight-1ns"},
+		{Date(1394, Mehr, 3, 0, 0, 0, 0, Iran()), false, true, false, "next midnight"},
+		{Date(1394, Mehr, 1, 21, 0, 0, 0, time.UTC), true, false, false, "previous day in UTC"},
+	}
+
+	for _, v := range vals {
+		if v.t.IsPast(now) != v.past || v.t.IsFuture(now) != v.future || v.t.IsToday(now) != v.today {
+			t.Error(
+				"For", v.name,
+				"expected", v.past, v.future, v.today,
+				"got", v.t.IsPast(now), v.t.IsFuture(now), v.t.IsToday(now),
+			)
+		}
+	}
+}
+
+func TestDualString(t *testing.T) {
+	vals := map[Time]string{
+		Date(1403, Farvardin, 15, 12, 0, 0, 0, Iran()): "۱۵ فروردین ۱۴۰۳ (۳ آوریل ۲۰۲۴)",
+		Date(1394, Dey, 11, 12, 0, 0, 0, Iran()):       "۱۱ دی ۱۳۹۴ (۱ ژانویه ۲۰۱۶)",
+		Date(1395, Esfand, 30, 12, 0, 0, 0, Iran()):    "۳۰ اسفند ۱۳۹۵ (۲۰ مارس ۲۰۱۷)",
+	}
+
+	for ti, expected := range vals {
+		if s := ti.DualString(); s != expected {
+			t.Error(
+				"For", "DualString()",
+				"expected", expected,
+				"got", s,
+			)
+		}
+	}
+
+	ti := Date(1403, Farvardin, 15, 12, 0, 0, 0, Iran())
+	if s := ti.FormatDual("d MMM yyyy", "2 January 2006"); s != "15 فروردین 1403 (3 April 2024)" {
+		t.Error(
+			"For", "FormatDual()",
+			"expected", "15 فروردین 1403 (3 April 2024)",
+			"got", s,
+		)
+	}
+
+	if GregorianMonthFa(time.August) != "اوت" {
+		t.Error(
+			"For", "GregorianMonthFa(time.August)",
+			"expected", "اوت",
+			"got", GregorianMonthFa(time.August),
+		)
+	}
+}
+
+func TestFirstDayWeek(t *testing.T) {
+	for m := Farvardin; m <= Esfand; m++ {
+		ti := Date(1395, m, 1, 12, 0, 0, 0, Iran())
+		if ti.MonthWeek() != 1 {
+			t.Error(
+				"For", fmt.Sprintf("Date(1395, %s, 1).MonthWeek()", m),
+				"expected", 1,
+				"got", ti.MonthWeek(),
+			)
+		}
+
+		if w := ti.LastMonthDay().MonthWeek(); ti.RMonthWeek() != w-1 || ti.LastMonthDay().RMonthWeek() != 0 {
+			t.Error(
+				"For", fmt.Sprintf("Date(1395, %s, 1).RMonthWeek()", m),
+				"expected", w-1,
+				"got", ti.RMonthWeek(),
+			)
+		}
+	}
+
+	ti := Date(1396, Farvardin, 1, 12, 0, 0, 0, Iran())
+	if ti.YearWeek() != 1 {
+		t.Error(
+			"For", "Date(1396, Farvardin, 1).YearWeek()",
+			"expected", 1,
+			"got", ti.YearWeek(),
+		)
+	}
+
+	// Farvardin 1, 1396 is Seshanbeh, so Farvardin 4 is the last day of the first week.
+	if w := ti.AddDate(0, 0, 3).YearWeek(); w != 1 {
+		t.Error(
+			"For", "Date(1396, Farvardin, 4).YearWeek()",
+			"expected", 1,
+			"got", w,
+		)
+	}
+
+	if w := ti.AddDate(0, 0, 4).YearWeek(); w != 2 {
+		t.Error(
+			"For", "Date(1396, Farvardin, 5).YearWeek()",
+			"expected", 2,
+			"got", w,
+		)
+	}
+
+	ti = Date(1394, Mehr, 2, 12, 59, 59, 0, Iran())
+	if s := ti.Format("W RW w"); s != "1 4 27" {
+		t.Error(
+			"For", "Format(W RW w)",
+			"expected", "1 4 27",
+			"got", s,
+		)
+	}
+}
+
+func TestRYearWeekLastDay(t *testing.T) {
+	// 1403 is a leap year and 1404 is not.
+	for _, p := range []pdate{{1402, Esfand, 29}, {1403, Esfand, 29}, {1403, Esfand, 30}, {1404, Esfand, 29}, {1405, Esfand, 29}} {
+		ti := Date(p.year, p.month, p.day, 12, 0, 0, 0, Iran())
+		if w := ti.RYearWeek(); w != 0 {
+			t.Error(
+				"For", ti.String(),
+				"expected", 0,
+				"got", w,
+			)
+		}
+	}
+
+	ti := Date(1404, Farvardin, 1, 12, 0, 0, 0, Iran())
+	if w := ti.RYearWeek(); w != ti.LastYearDay().YearWeek()-1 {
+		t.Error(
+			"For", ti.String(),
+			"expected", ti.LastYearDay().YearWeek()-1,
+			"got", w,
+		)
+	}
+}
+
+func TestToUTC(t *testing.T) {
+	// Tehran is UTC+04:30 in summer of 1394 like Kabul.
+	tehran := Date(1394, Tir, 15, 13, 0, 0, 0, Iran()).ToUTC()
+	kabul := Date(1394, Tir, 15, 13, 0, 0, 0, Afghanistan()).ToUTC()
+
+	if tehran.String() != kabul.String() || tehran.Location() != time.UTC {
+		t.Error(
+			"For", "ToUTC()",
+			"expected", tehran.String(),
+			"got", kabul.String(),
+		)
+	}
+
+	if tehran.Format("yyyy/MM/dd HH:mm") != "1394/04/15 08:30" {
+		t.Error(
+			"For", "ToUTC()",
+			"expected", "1394/04/15 08:30",
+			"got", tehran.Format("yyyy/MM/dd HH:mm"),
+		)
+	}
+
+	tehran = Date(1394, Mehr, 2, 13, 0, 0, 0, Iran()).ToUTC()
+	kabul = Date(1394, Mehr, 2, 13, 30, 0, 0, Afghanistan()).ToUTC()
+	if tehran.String() == kabul.String() {
+		t.Error(
+			"For", "ToUTC() in autumn",
+			"expected", "different instants",
+			"got", kabul.String(),
+		)
+	}
+
+	early := Date(1394, Mehr, 2, 2, 0, 0, 0, Iran()).ToUTC()
+	if early.Format("yyyy/MM/dd HH:mm") != "1394/07/01 22:30" {
+		t.Error(
+			"For", "ToUTC() of an early morning",
+			"expected", "1394/07/01 22:30",
+			"got", early.Format("yyyy/MM/dd HH:mm"),
+		)
+	}
+}
+
+func TestLists(t *testing.T) {
+	if len(Months()) != 12 || len(MonthNames()) != 12 || len(DariMonthNames()) != 12 {
+		t.Error(
+			"For", "Months(), MonthNames(), DariMonthNames()",
+			"expected", 12,
+			"got", len(Months()), len(MonthNames()), len(DariMonthNames()),
+		)
+	}
+
+	if len(Weekdays()) != 7 || len(WeekdayNames()) != 7 {
+		t.Error(
+			"For", "Weekdays(), WeekdayNames()",
+			"expected", 7,
+			"got", len(Weekdays()), len(WeekdayNames()),
+		)
+	}
+
+	for i, m := range Months() {
+		if m.String() != MonthNames()[i] || m.Dari() != DariMonthNames()[i] || m != monthPersianNames[i].month {
+			t.Error(
+				"For", fmt.Sprintf("Months()[%d]", i),
+				"expected", monthPersianNames[i].name,
+				"got", MonthNames()[i],
+			)
+		}
+	}
+
+	for i, d := range Weekdays() {
+		if d.String() != WeekdayNames()[i] {
+			t.Error(
+				"For", fmt.Sprintf("Weekdays()[%d]", i),
+				"expected", d.String(),
+				"got", WeekdayNames()[i],
+			)
+		}
+	}
+
+	names := MonthNames()
+	names[0] = ""
+	if MonthNames()[0] != Farvardin.String() {
+		t.Error(
+			"For", "MonthNames()[0]",
+			"expected", Farvardin.String(),
+			"got", MonthNames()[0],
+		)
+	}
+}
+
+func TestWeeksBetween(t *testing.T) {
+	ti := Date(1394, Azar, 25, 12, 0, 0, 0, Iran())
+
+	vals := map[int]int{
+		0:  0,
+		6:  0,
+		7:  1,
+		8:  1,
+		-6: 0,
+		-7: -1,
+		-8: -1,
+		60: 8,
+	}
+
+	for days, expected := range vals {
+		if w := ti.WeeksBetween(ti.AddDate(0, 0, days)); w != expected {
+			t.Error(
+				"For", fmt.Sprintf("WeeksBetween(%d days)", days),
+				"expected", expected,
+				"got", w,
+			)
+		}
+	}
+
+	if w := ti.WeeksBetween(ti.AddDate(0, 0, 7).Add(-time.Nanosecond)); w != 0 {
+		t.Error(
+			"For", "WeeksBetween(7 days - 1ns)",
+			"expected", 0,
+			"got", w,
+		)
+	}
+
+	if w := ti.WeeksBetween(ti.AddDate(0, 0, -7).Add(time.Nanosecond)); w != 0 {
+		t.Error(
+			"For", "WeeksBetween(-7 days + 1ns)",
+			"expected", 0,
+			"got", w,
+		)
+	}
+}
+
+func TestMonthLength(t *testing.T) {
+	vals := [12][3]int{
+		{31, 31, 0},
+		{31, 31, 31},
+		{31, 31, 62},
+		{31, 31, 93},
+		{31, 31, 124},
+		{31, 31, 155},
+		{30, 30, 186},
+		{30, 30, 216},
+		{30, 30, 246},
+		{30, 30, 276},
+		{30, 30, 306},
+		{29, 30, 336},
+	}
+
+	for i, v := range vals {
+		m := Month(i + 1)
+		if m.Length(false) != v[0] || m.Length(true) != v[1] || m.DaysBeforeStart() != v[2] {
+			t.Error(
+				"For", m.String(),
+				"expected", v,
+				"got", m.Length(false), m.Length(true), m.DaysBeforeStart(),
+			)
+		}
+
+		if d := Date(1394, m, 1, 12, 0, 0, 0, Iran()).LastMonthDay().Day(); d != m.Length(false) {
+			t.Error(
+				"For", m.String()+" 1394",
+				"expected", m.Length(false),
+				"got", d,
+			)
+		}
+
+		if d := Date(1395, m, 1, 12, 0, 0, 0, Iran()).LastMonthDay().Day(); d != m.Length(true) {
+			t.Error(
+				"For", m.String()+" 1395",
+				"expected", m.Length(true),
+				"got", d,
+			)
+		}
+	}
+
+	for _, m := range []Month{0, 13} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error(
+						"For", fmt.Sprintf("Month(%d).Length(false)", m),
+						"expected", "panic",
+						"got", nil,
+					)
+				}
+			}()
+			m.Length(false)
+		}()
+	}
+}
+
+func TestRoundToDay(t *testing.T) {
+	vals := []struct {
+		t        Time
+		expected string
+	}{
+		{Date(1394, Mehr, 2, 11, 59, 59, 999999999, Iran()), "1394/07/02 00:00:00.000"},
+		{Date(1394, Mehr, 2, 12, 0, 0, 0, Iran()), "1394/07/03 00:00:00.000"},
+		{Date(1394, Mehr, 2, 0, 0, 0, 0, Iran()), "1394/07/02 00:00:00.000"},
+		{Date(1395, Esfand, 30, 11, 59, 0, 0, Iran()), "1395/12/30 00:00:00.000"},
+		{Date(1395, Esfand, 30, 12, 0, 0, 0, Iran()), "1396/01/01 00:00:00.000"},
+		{Date(1394, Esfand, 29, 23, 0, 0, 0, Iran()), "1395/01/01 00:00:00.000"},
+	}
+
+	for _, v := range vals {
+		if s := v.t.RoundToDay().Format("yyyy/MM/dd HH:mm:ss.S"); s != v.expected {
+			t.Error(
+				"For", v.t.String(),
+				"expected", v.expected,
+				"got", s,
+			)
+		}
+	}
+}
+
+func TestRoundToWeek(t *testing.T) {
+	// 1403/12/04 is a Shanbeh.
+	vals := []struct {
+		t        Time
+		start    Weekday
+		expected string
+	}{
+		{Date(1403, Esfand, 4, 0, 0, 0, 0, Iran()), Shanbeh, "1403/12/04 00:00:00.000"},
+		{Date(1403, Esfand, 7, 11, 59, 59, 999999999, Iran()), Shanbeh, "1403/12/04 00:00:00.000"},
+		{Date(1403, Esfand, 7, 12, 0, 0, 0, Iran()), Shanbeh, "1403/12/11 00:00:00.000"},
+		{Date(1403, Esfand, 10, 23, 59, 59, 0, Iran()), Shanbeh, "1403/12/11 00:00:00.000"},
+		{Date(1403, Esfand, 28, 18, 0, 0, 0, Iran()), Shanbeh, "1404/01/02 00:00:00.000"},
+		{Date(1403, Esfand, 4, 0, 0, 0, 0, Iran()), Yekshanbeh, "1403/12/05 00:00:00.000"},
+		{Date(1403, Esfand, 1, 11, 0, 0, 0, Iran()), Yekshanbeh, "1403/11/28 00:00:00.000"},
+		{Date(1403, Esfand, 1, 12, 0, 0, 0, Iran()), Yekshanbeh, "1403/12/05 00:00:00.000"},
+	}
+
+	for _, v := range vals {
+		r := v.t.RoundToWeekFrom(v.start)
+		if s := r.Format("yyyy/MM/dd HH:mm:ss.S"); s != v.expected || r.Weekday() != v.start {
+			t.Error(
+				"For", v.t.String(), v.start,
+				"expected", v.expected,
+				"got", s,
+			)
+		}
+		if v.start == Shanbeh && v.t.RoundToWeek().String() != r.String() {
+			t.Error(
+				"For", v.t.String(),
+				"expected", r.String(),
+				"got", v.t.RoundToWeek().String(),
+			)
+		}
+	}
+}
+
+func TestRoundToHour(t *testing.T) {
+	vals := []struct {
+		t        Time
+		expected string
+	}{
+		{Date(1394, Mehr, 2, 11, 29, 59, 999999999, Iran()), "1394/07/02 11:00:00.000"},
+		{Date(1394, Mehr, 2, 11, 30, 0, 0, Iran()), "1394/07/02 12:00:00.000"},
+		{Date(1395, Esfand, 30, 23, 45, 0, 0, Iran()), "1396/01/01 00:00:00.000"},
+	}
+
+	for _, v := range vals {
+		if s := v.t.RoundToHour().Format("yyyy/MM/dd HH:mm:ss.S"); s != v.expected {
+			t.Error(
+				"For", v.t.String(),
+				"expected", v.expected,
+				"got", s,
+			)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	vals := map[Time]string{
+		Date(1394, Mehr, 2, 12, 59, 59, 50260050, Iran()):         "1394-07-02T12:59:59.05026005+03:30",
+		Date(1394, Mehr, 2, 12, 59, 59, 0, Iran()):                "1394-07-02T12:59:59+03:30",
+		Date(1394, Mehr, 2, 2, 3, 4, 100000000, time.UTC):         "1394-07-02T02:03:04.1Z",
+		Date(1394, Mehr, 2, 2, 3, 4, 1, Afghanistan()):            "1394-07-02T02:03:04.000000001+04:30",
+		Date(394, Mehr, 2, 2, 3, 4, 0, time.FixedZone("", -9000)): "0394-07-02T02:03:04-02:30",
+	}
+
+	for ti, expected := range vals {
+		if s := ti.String(); s != expected {
+			t.Error(
+				"For", "String()",
+				"expected", expected,
+				"got", s,
+			)
+		}
+	}
+}
+
+func TestZoneOffsetNegative(t *testing.T) {
+	ti := Date(1403, Dey, 1, 12, 0, 0, 0, time.FixedZone("", -12600))
+	vals := map[string]string{
+		"-07:00": "-03:30",
+		"-0700":  "-0330",
+		"-07":    "-03",
+		"Z07:00": "-03:30",
+	}
+
+	for f, expected := range vals {
+		if s := ti.ZoneOffset(f); s != expected {
+			t.Error(
+				"For", f,
+				"expected", expected,
+				"got", s,
+			)
+		}
+	}
+}
+
+func TestPersianString(t *testing.T) {
+	ti := Date(1394, Mehr, 2, 9, 5, 59, 50260050, Iran())
+	if s := ti.PersianString(); s != "۱۳۹۴/۰۷/۰۲ ۰۹:۰۵:۵۹" {
+		t.Error(
+			"For", "PersianString()",
+			"expected", "۱۳۹۴/۰۷/۰۲ ۰۹:۰۵:۵۹",
+			"got", s,
+		)
+	}
+}
+
+func TestPad(t *testing.T) {
+	if s := Date(800, Farvardin, 2, 3, 4, 5, 6, Iran()).Pad(); s != "0800/01/02 03:04:05" {
+		t.Error(
+			"For", "Pad()",
+			"expected", "0800/01/02 03:04:05",
+			"got", s,
+		)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	var prev Time
+	for i := 0; i < 1000; i++ {
+		ti := Unix(r.Int63n(3e9)-1e9, 0, time.UTC)
+		s := ti.Pad()
+		if len(s) != 19 {
+			t.Error(
+				"For", ti.String(),
+				"expected", 19, "characters",
+				"got", s,
+			)
+		}
+		if i > 0 && (s < prev.Pad()) != ti.Time().Before(prev.Time()) {
+			t.Error(
+				"For", ti.String(), "and", prev.String(),
+				"expected", "the same order",
+				"got", s, prev.Pad(),
+			)
+		}
+		prev = ti
+	}
+}
+
+func TestClockFa(t *testing.T) {
+	vals := []struct {
+		ti                      Time
+		full, clock, twelveHour string
+	}{
+		{Date(1394, Mehr, 2, 9, 5, 59, 50260050, Iran()), "۰۹:۰۵:۵۹", "۰۹:۰۵", "۹:۰۵ ق.ظ"},
+		{Date(1394, Mehr, 2, 14, 5, 9, 0, Iran()), "۱۴:۰۵:۰۹", "۱۴:۰۵", "۲:۰۵ ب.ظ"},
+		{Date(1394, Mehr, 2, 0, 30, 0, 0, Iran()), "۰۰:۳۰:۰۰", "۰۰:۳۰", "۱۲:۳۰ ق.ظ"},
+		{Date(1394, Mehr, 2, 23, 59, 59, 0, Iran()), "۲۳:۵۹:۵۹", "۲۳:۵۹", "۱۱:۵۹ ب.ظ"},
+	}
+
+	for _, v := range vals {
+		if s := v.ti.TimeString(); s != v.full {
+			t.Error(
+				"For", v.ti.String(),
+				"expected", v.full,
+				"got", s,
+			)
+		}
+		if s := v.ti.ClockFa(); s != v.clock {
+			t.Error(
+				"For", v.ti.String(),
+				"expected", v.clock,
+				"got", s,
+			)
+		}
+		if s := v.ti.ClockFa12(); s != v.twelveHour {
+			t.Error(
+				"For", v.ti.String(),
+				"expected", v.twelveHour,
+				"got", s,
+			)
+		}
+	}
+}
+
+func TestAddWeeks(t *testing.T) {
+	ti := Date(1394, Mehr, 28, 12, 59, 59, 0, Iran())
+
+	w := ti.AddWeeks(1)
+	if w.Year() != 1394 || w.Month() != Aban || w.Day() != 5 || w.Weekday() != ti.Weekday() || w.Hour() != 12 {
+		t.Error(
+			"For", "AddWeeks(1)",
+			"expected", "1394 Aban 5 "+ti.Weekday().String(),
+			"got", fmt.Sprintf("%d %s %d %s", w.Year(), w.Month(), w.Day(), w.Weekday()),
+		)
+	}
+
+	w = ti.AddWeeks(-5)
+	if w.Year() != 1394 || w.Month() != Shahrivar || w.Day() != 24 || w.Weekday() != ti.Weekday() {
+		t.Error(
+			"For", "AddWeeks(-5)",
+			"expected", "1394 Shahrivar 24 "+ti.Weekday().String(),
+			"got", fmt.Sprintf("%d %s %d %s", w.Year(), w.Month(), w.Day(), w.Weekday()),
+		)
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	ti := Date(1402, Tir, 10, 8, 30, 0, 0, Iran())
+
+	vals := []struct {
+		month Month
+		day   int
+		next  pdate
+		prev  pdate
+	}{
+		{Farvardin, 1, pdate{1403, Farvardin, 1}, pdate{1402, Farvardin, 1}},
+		{Tir, 10, pdate{1402, Tir, 10}, pdate{1402, Tir, 10}},
+		{Tir, 11, pdate{1402, Tir, 11}, pdate{1401, Tir, 11}},
+		{Mehr, 2, pdate{1402, Mehr, 2}, pdate{1401, Mehr, 2}},
+		{Esfand, 30, pdate{1402, Esfand, 29}, pdate{1401, Esfand, 29}},
+	}
+
+	for _, v := range vals {
+		for _, c := range []struct {
+			got      Time
+			expected pdate
+		}{{ti.NextOccurrence(v.month, v.day), v.next}, {ti.PrevOccurrence(v.month, v.day), v.prev}} {
+			if c.got.Year() != c.expected.year || c.got.Month() != c.expected.month || c.got.Day() != c.expected.day || c.got.Hour() != 8 || c.got.Minute() != 30 {
+				t.Error(
+					"For", fmt.Sprintf("%s %d", v.month, v.day),
+					"expected", fmt.Sprintf("%d %s %d", c.expected.year, c.expected.month, c.expected.day),
+					"got", c.got.String(),
+				)
+			}
+		}
+	}
+
+	leap := Date(1403, Esfand, 30, 0, 0, 0, 0, Iran())
+	if next := leap.NextOccurrence(Esfand, 30); next.Year() != 1403 || next.Day() != 30 {
+		t.Error(
+			"For", "1403 Esfand 30",
+			"expected", "1403/12/30",
+			"got", next.String(),
+		)
+	}
+}
+
+func TestQuarterBounds(t *testing.T) {
+	vals := []struct {
+		date    pdate
+		quarter int
+		begin   pdate
+		end     pdate
+	}{
+		{pdate{1402, Farvardin, 1}, 1, pdate{1402, Farvardin, 1}, pdate{1402, Khordad, 31}},
+		{pdate{1402, Shahrivar, 31}, 2, pdate{1402, Tir, 1}, pdate{1402, Shahrivar, 31}},
+		{pdate{1402, Mehr, 15}, 3, pdate{1402, Mehr, 1}, pdate{1402, Azar, 30}},
+		{pdate{1402, Bahman, 10}, 4, pdate{1402, Dey, 1}, pdate{1402, Esfand, 29}},
+		{pdate{1403, Dey, 1}, 4, pdate{1403, Dey, 1}, pdate{1403, Esfand, 30}},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.date.year, v.date.month, v.date.day, 10, 20, 30, 0, Iran())
+		if q := ti.Quarter(); q != v.quarter {
+			t.Error(
+				"For", ti.String(),
+				"expected", v.quarter,
+				"got", q,
+			)
+		}
+
+		begin := ti.BeginningOfQuarter()
+		if begin.Year() != v.begin.year || begin.Month() != v.begin.month || begin.Day() != v.begin.day ||
+			begin.Hour() != 0 || begin.Minute() != 0 || begin.Second() != 0 || begin.Nanosecond() != 0 {
+			t.Error(
+				"For", ti.String(),
+				"expected", v.begin,
+				"got", begin.String(),
+			)
+		}
+
+		end := ti.EndOfQuarter()
+		if end.Year() != v.end.year || end.Month() != v.end.month || end.Day() != v.end.day ||
+			end.Hour() != 23 || end.Minute() != 59 || end.Second() != 59 || end.Nanosecond() != 999999999 {
+			t.Error(
+				"For", ti.String(),
+				"expected", v.end,
+				"got", end.String(),
+			)
+		}
+	}
+}
+
+func TestOnWeekday(t *testing.T) {
+	// 1402/07/05 is a Charshanbeh.
+	for _, from := range []pdate{{1402, Mehr, 1}, {1402, Mehr, 5}, {1402, Mehr, 7}} {
+		ti := Date(from.year, from.month, from.day, 9, 15, 0, 0, Iran())
+		for wd := Shanbeh; wd <= Jomeh; wd++ {
+			d := ti.OnWeekday(wd)
+			if d.Weekday() != wd || d.Day() != 1+int(wd) || d.Month() != Mehr || d.Hour() != 9 || d.Minute() != 15 {
+				t.Error(
+					"For", fmt.Sprintf("%s of %d", wd, from.day),
+					"expected", fmt.Sprintf("1402/07/%02d 09:15", 1+int(wd)),
+					"got", d.String(),
+				)
+			}
+		}
+	}
+}
+
+func TestWeekDayFrom(t *testing.T) {
+	// 1402/07/05 is a Charshanbeh.
+	ti := Date(1402, Mehr, 5, 9, 15, 0, 0, Iran())
+
+	vals := []struct {
+		start       Weekday
+		first, last int
+	}{
+		{Shanbeh, 1, 7},
+		{Yekshanbeh, 2, 8},
+		{Doshanbeh, 3, 9},
+		{Charshanbeh, 5, 11},
+		{Panjshanbeh, -1, 5},
+	}
+
+	for _, v := range vals {
+		first, last := ti.FirstWeekDayFrom(v.start), ti.LastWeekDayFrom(v.start)
+		if first.Weekday() != v.start || first.Hour() != 9 || !first.Time().Equal(ti.AddDate(0, 0, v.first-5).Time()) {
+			t.Error(
+				"For", v.start,
+				"expected", v.first,
+				"got", first.String(),
+			)
+		}
+		if last.Weekday() != (v.start+6)%7 || !last.Time().Equal(ti.AddDate(0, 0, v.last-5).Time()) {
+			t.Error(
+				"For", v.start,
+				"expected", v.last,
+				"got", last.String(),
+			)
+		}
+	}
+
+	if d := ti.FirstWeekDayFrom(Shanbeh); !d.Time().Equal(ti.FirstWeekDay().Time()) {
+		t.Error(
+			"For", "Shanbeh",
+			"expected", ti.FirstWeekDay().String(),
+			"got", d.String(),
+		)
+	}
+}
+
+func TestWeekFrom(t *testing.T) {
+	// 1402/07/01 is a Shanbeh and 1402/01/01 is a Tuesday (Seshanbeh).
+	vals := []struct {
+		date            pdate
+		start           Weekday
+		month, yearWeek int
+	}{
+		{pdate{1402, Mehr, 1}, Shanbeh, 1, 28},
+		{pdate{1402, Mehr, 1}, Yekshanbeh, 1, 27},
+		{pdate{1402, Mehr, 2}, Yekshanbeh, 2, 28},
+		{pdate{1402, Mehr, 2}, Doshanbeh, 1, 27},
+		{pdate{1402, Mehr, 3}, Doshanbeh, 2, 28},
+		{pdate{1402, Farvardin, 1}, Doshanbeh, 1, 1},
+		{pdate{1402, Farvardin, 6}, Doshanbeh, 1, 1},
+		{pdate{1402, Farvardin, 7}, Doshanbeh, 2, 2},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.date.year, v.date.month, v.date.day, 0, 0, 0, 0, Iran())
+		if w := ti.MonthWeekFrom(v.start); w != v.month {
+			t.Error(
+				"For", fmt.Sprintf("%s from %s", ti.String(), v.start),
+				"expected", v.month,
+				"got", w,
+			)
+		}
+		if w := ti.YearWeekFrom(v.start); w != v.yearWeek {
+			t.Error(
+				"For", fmt.Sprintf("%s from %s", ti.String(), v.start),
+				"expected", v.yearWeek,
+				"got", w,
+			)
+		}
+		if ti.MonthWeekFrom(Shanbeh) != ti.MonthWeek() || ti.YearWeekFrom(Shanbeh) != ti.YearWeek() {
+			t.Error(
+				"For", ti.String(),
+				"expected", "MonthWeek and YearWeek",
+				"got", ti.MonthWeekFrom(Shanbeh), ti.YearWeekFrom(Shanbeh),
+			)
+		}
+	}
+}
+
+func TestDateTime(t *testing.T) {
+	zone := time.FixedZone("+0330", 12600)
+
+	ti := Date(1402, Dey, 10, 23, 45, 0, 0, zone)
+	d := ti.DateTime()
+	if !d.Equal(time.Date(2023, time.December, 31, 0, 0, 0, 0, zone)) || d.Location() != zone {
+		t.Error(
+			"For", ti.String(),
+			"expected", "2023-12-31T00:00:00+03:30",
+			"got", d.Format(time.RFC3339Nano),
+		)
+	}
+
+	g := time.Date(2023, time.December, 31, 23, 45, 0, 0, zone)
+	p := DateFromGregorian(g)
+	if p.Year() != 1402 || p.Month() != Dey || p.Day() != 10 || p.Hour() != 0 || p.Minute() != 0 || p.Location() != zone {
+		t.Error(
+			"For", g.Format(time.RFC3339),
+			"expected", "1402-10-10T00:00:00+03:30",
+			"got", p.String(),
+		)
+	}
+
+	// The UTC date of g is still 2023-12-31 while its date is 2024-01-01 in +03:30.
+	g = time.Date(2023, time.December, 31, 21, 0, 0, 0, time.UTC).In(zone)
+	if p := DateFromGregorian(g); p.Day() != 11 || p.Hour() != 0 {
+		t.Error(
+			"For", g.Format(time.RFC3339),
+			"expected", "1402-10-11T00:00:00+03:30",
+			"got", p.String(),
+		)
+	}
+}
+
+func TestWith(t *testing.T) {
+	ti := Date(1403, Shahrivar, 31, 10, 20, 30, 40, Iran())
+	orig := ti.String()
+
+	if d := ti.WithMonth(Mehr); d.Month() != Mehr || d.Day() != 30 || d.Weekday() != Doshanbeh {
+		t.Error(
+			"For", "WithMonth(Mehr)",
+			"expected", "1403-07-30",
+			"got", d.String(),
+		)
+	}
+	if d := ti.WithMonth(Mehr).WithDay(1); d.String() != "1403-07-01T10:20:30.00000004+03:30" {
+		t.Error(
+			"For", "WithMonth(Mehr).WithDay(1)",
+			"expected", "1403-07-01T10:20:30.00000004+03:30",
+			"got", d.String(),
+		)
+	}
+	if d := ti.WithMonth(Esfand).WithYear(1404); d.Year() != 1404 || d.Day() != 29 {
+		t.Error(
+			"For", "WithMonth(Esfand).WithYear(1404)",
+			"expected", "1404-12-29",
+			"got", d.String(),
+		)
+	}
+	if d := ti.WithDay(40); d.Day() != 31 {
+		t.Error(
+			"For", "WithDay(40)",
+			"expected", 31,
+			"got", d.Day(),
+		)
+	}
+	if d := ti.WithHour(25).WithMinute(-1).WithSecond(5).WithNanosecond(6); d.Hour() != 23 || d.Minute() != 0 || d.Second() != 5 || d.Nanosecond() != 6 {
+		t.Error(
+			"For", "WithHour(25).WithMinute(-1).WithSecond(5).WithNanosecond(6)",
+			"expected", "23:00:05.000000006",
+			"got", d.String(),
+		)
+	}
+	if ti.String() != orig {
+		t.Error(
+			"For", "receiver",
+			"expected", orig,
+			"got", ti.String(),
+		)
+	}
+}
+
+func TestUnixDay(t *testing.T) {
+	for _, loc := range []*time.Location{Iran(), Afghanistan(), time.UTC} {
+		start := Date(1403, Esfand, 30, 0, 0, 0, 0, loc)
+		for _, ti := range []Time{start, Date(1403, Esfand, 30, 12, 30, 0, 0, loc), Date(1403, Esfand, 30, 23, 59, 59, 999999999, loc)} {
+			if d := ti.UnixDay(); d != start.Unix() {
+				t.Error(
+					"For", ti.String(),
+					"expected", start.Unix(),
+					"got", d,
+				)
+			}
+		}
+		if next := Date(1404, Farvardin, 1, 0, 0, 0, 0, loc); next.UnixDay() != start.Unix()+86400 {
+			t.Error(
+				"For", next.String(),
+				"expected", start.Unix()+86400,
+				"got", next.UnixDay(),
+			)
+		}
+	}
+
+	// 2025-03-20T00:00:00+03:30
+	if d := Date(1403, Esfand, 30, 18, 0, 0, 0, Iran()).UnixDay(); d != 1742416200 {
+		t.Error(
+			"For", "1403/12/30 in Iran",
+			"expected", 1742416200,
+			"got", d,
+		)
+	}
+}
+
+func TestInYear(t *testing.T) {
+	vals := []struct {
+		from     pdate
+		year     int
+		expected pdate
+	}{
+		{pdate{1403, Esfand, 30}, 1404, pdate{1404, Esfand, 29}},
+		{pdate{1403, Esfand, 30}, 1407, pdate{1407, Esfand, 29}},
+		{pdate{1403, Esfand, 30}, 1408, pdate{1408, Esfand, 30}},
+		{pdate{1404, Esfand, 29}, 1403, pdate{1403, Esfand, 29}},
+		{pdate{1403, Mehr, 2}, 1394, pdate{1394, Mehr, 2}},
+		{pdate{1403, Farvardin, 31}, 1300, pdate{1300, Farvardin, 31}},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.from.year, v.from.month, v.from.day, 10, 20, 30, 40, Iran())
+		d := ti.InYear(v.year)
+		expected := Date(v.expected.year, v.expected.month, v.expected.day, 10, 20, 30, 40, Iran())
+		if d.String() != expected.String() || d.Weekday() != expected.Weekday() {
+			t.Error(
+				"For", fmt.Sprintf("%s.InYear(%d)", ti.String(), v.year),
+				"expected", expected.String(),
+				"got", d.String(),
+			)
+		}
+	}
+}
+
+func TestFiscalYear(t *testing.T) {
+	vals := []struct {
+		date        pdate
+		month       Month
+		day         int
+		year        int
+		start, last pdate
+	}{
+		{pdate{1403, Farvardin, 5}, Mehr, 1, 1402, pdate{1402, Mehr, 1}, pdate{1403, Shahrivar, 31}},
+		{pdate{1403, Shahrivar, 31}, Mehr, 1, 1402, pdate{1402, Mehr, 1}, pdate{1403, Shahrivar, 31}},
+		{pdate{1403, Mehr, 1}, Mehr, 1, 1403, pdate{1403, Mehr, 1}, pdate{1404, Shahrivar, 31}},
+		{pdate{1403, Esfand, 30}, 0, 0, 1403, pdate{1403, Farvardin, 1}, pdate{1403, Esfand, 30}},
+		{pdate{1403, Farvardin, 1}, Farvardin, 1, 1403, pdate{1403, Farvardin, 1}, pdate{1403, Esfand, 30}},
+		{pdate{1403, Esfand, 29}, Esfand, 30, 1402, pdate{1402, Esfand, 29}, pdate{1403, Esfand, 29}},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.date.year, v.date.month, v.date.day, 12, 0, 0, 0, Iran())
+		if y := ti.FiscalYear(v.month, v.day); y != v.year {
+			t.Error(
+				"For", ti.String(),
+				"expected", v.year,
+				"got", y,
+			)
+		}
+
+		start, end := ti.FiscalYearBounds(v.month, v.day)
+		if start.Year() != v.start.year || start.Month() != v.start.month || start.Day() != v.start.day || start.Hour() != 0 {
+			t.Error(
+				"For", ti.String(),
+				"expected", v.start,
+				"got", start.String(),
+			)
+		}
+		if end.Year() != v.last.year || end.Month() != v.last.month || end.Day() != v.last.day || end.Hour() != 23 || end.Nanosecond() != 999999999 {
+			t.Error(
+				"For", ti.String(),
+				"expected", v.last,
+				"got", end.String(),
+			)
+		}
+	}
+}
+
+func TestGregorianSwitch(t *testing.T) {
+	// 1582-10-15 (Gregorian) is JDN 2299161, i.e. 208 days after Nowruz 961 (JDN 2298953).
+	vals := []struct {
+		g gdate
+		p pdate
+	}{
+		{gdate{1582, time.October, 15}, pdate{961, Mehr, 23}},
+		{gdate{1582, time.October, 14}, pdate{961, Mehr, 22}},
+		{gdate{1582, time.October, 4}, pdate{961, Mehr, 12}},
+		{gdate{1582, time.March, 21}, pdate{961, Farvardin, 1}},
+		{gdate{2000, time.January, 1}, pdate{1378, Dey, 11}},
+	}
+
+	for _, v := range vals {
+		g := time.Date(v.g.year, v.g.month, v.g.day, 0, 0, 0, 0, time.UTC)
+		if p := New(g); p.Year() != v.p.year || p.Month() != v.p.month || p.Day() != v.p.day {
+			t.Error(
+				"For", g.Format("2006-01-02"),
+				"expected", v.p,
+				"got", p.String(),
+			)
+		}
+		if r := Date(v.p.year, v.p.month, v.p.day, 0, 0, 0, 0, time.UTC).Time(); !r.Equal(g) {
+			t.Error(
+				"For", v.p,
+				"expected", g.Format("2006-01-02"),
+				"got", r.Format("2006-01-02"),
+			)
+		}
+	}
+
+	prev := New(time.Date(1582, time.September, 30, 12, 0, 0, 0, time.UTC))
+	for g := time.Date(1582, time.October, 1, 12, 0, 0, 0, time.UTC); g.Month() == time.October; g = g.AddDate(0, 0, 1) {
+		p := New(g)
+		if r := p.Time(); !r.Equal(g) {
+			t.Error(
+				"For", g.Format("2006-01-02"),
+				"expected", g.Format("2006-01-02"),
+				"got", r.Format("2006-01-02"),
+			)
+		}
+		if d := prev.AddDate(0, 0, 1); d.Day() != p.Day() || d.Weekday() != p.Weekday() || p.Weekday().ToStdWeekday() != g.Weekday() {
+			t.Error(
+				"For", g.Format("2006-01-02"),
+				"expected", d.String(),
+				"got", p.String(),
+			)
+		}
+		prev = p
+	}
+}
+
+func TestDaysUntil(t *testing.T) {
+	ti := Date(1402, Esfand, 25, 23, 0, 0, 0, Iran())
+
+	vals := []struct {
+		u    Time
+		days int
+	}{
+		{Date(1402, Esfand, 25, 1, 0, 0, 0, Iran()), 0},
+		{Date(1402, Esfand, 26, 1, 0, 0, 0, Iran()), 1},
+		{Date(1403, Farvardin, 1, 0, 0, 0, 0, Iran()), 5},
+		{Date(1403, Farvardin, 12, 12, 0, 0, 0, Iran()), 16},
+		{Date(1403, Ordibehesht, 1, 0, 0, 0, 0, Iran()), 36},
+		{Date(1402, Bahman, 25, 23, 59, 0, 0, Iran()), -30},
+		// 1402/12/25 21:00 UTC is 1402/12/26 00:30 in Tehran.
+		{Date(1402, Esfand, 25, 21, 0, 0, 0, time.UTC), 1},
+	}
+
+	for _, v := range vals {
+		if d := ti.DaysUntil(v.u); d != v.days {
+			t.Error(
+				"For", v.u.String(),
+				"expected", v.days,
+				"got", d,
+			)
+		}
+	}
+}
+
+func TestFormatGregorian(t *testing.T) {
+	for _, ti := range []Time{
+		Date(1394, Mehr, 2, 12, 59, 59, 0, Iran()),
+		Date(1403, Esfand, 30, 23, 0, 0, 0, Iran()),
+		Date(1403, Dey, 11, 0, 0, 0, 0, Afghanistan()),
+	} {
+		g := ti.Time()
+		expected := fmt.Sprintf("%04d-%02d-%02d (%s)", g.Year(), g.Month(), g.Day(), ti.Format("yyyy/MM/dd"))
+		if s := ti.Format("gyyyy-gMM-gdd (yyyy/MM/dd)"); s != expected {
+			t.Error(
+				"For", ti.String(),
+				"expected", expected,
+				"got", s,
+			)
+		}
+
+		expected = ti.Format("MMM MMI MM") + " / " + g.Month().String()
+		if s := ti.Format("MMM MMI MM / gMMM"); s != expected {
+			t.Error(
+				"For", ti.String(),
+				"expected", expected,
+				"got", s,
+			)
+		}
+	}
+
+	if _, err := Parse("gyyyy", "2015"); err == nil {
+		t.Error(
+			"For", "gyyyy",
+			"expected", "error",
+			"got", nil,
+		)
+	}
+}
+
+func TestMonthNextPrev(t *testing.T) {
+	for m := Farvardin; m <= Esfand; m++ {
+		expected := m + 1
+		if m == Esfand {
+			expected = Farvardin
+		}
+		if n := m.Next(); n != expected {
+			t.Error(
+				"For", m,
+				"expected", expected,
+				"got", n,
+			)
+		}
+		if p := expected.Prev(); p != m {
+			t.Error(
+				"For", expected,
+				"expected", m,
+				"got", p,
+			)
+		}
+	}
+}
+
+func TestWeekdayNextPrev(t *testing.T) {
+	for d := Shanbeh; d <= Jomeh; d++ {
+		expected := d + 1
+		if d == Jomeh {
+			expected = Shanbeh
+		}
+		if n := d.Next(); n != expected {
+			t.Error(
+				"For", d,
+				"expected", expected,
+				"got", n,
+			)
+		}
+		if p := expected.Prev(); p != d {
+			t.Error(
+				"For", expected,
+				"expected", d,
+				"got", p,
+			)
+		}
+	}
+
+	if Shanbeh.Prev() != Jomeh || Esfand.Next() != Farvardin {
+		t.Error(
+			"For", "wraparound",
+			"expected", "Jomeh and Farvardin",
+			"got", Shanbeh.Prev(), Esfand.Next(),
+		)
+	}
+}
+
+func TestDateFromYearDay(t *testing.T) {
+	vals := []struct {
+		year, yearDay int
+		expected      pdate
+	}{
+		{1403, 1, pdate{1403, Farvardin, 1}},
+		{1403, 186, pdate{1403, Shahrivar, 31}},
+		{1403, 187, pdate{1403, Mehr, 1}},
+		{1403, 365, pdate{1403, Esfand, 29}},
+		{1403, 366, pdate{1403, Esfand, 30}},
+		{1404, 1, pdate{1404, Farvardin, 1}},
+		{1404, 186, pdate{1404, Shahrivar, 31}},
+		{1404, 365, pdate{1404, Esfand, 29}},
+	}
+
+	for _, v := range vals {
+		ti, err := DateFromYearDay(v.year, v.yearDay, Iran())
+		if err != nil || ti.Year() != v.expected.year || ti.Month() != v.expected.month || ti.Day() != v.expected.day || ti.YearDay() != v.yearDay {
+			t.Error(
+				"For", fmt.Sprintf("%d/%d", v.year, v.yearDay),
+				"expected", v.expected,
+				"got", ti.String(), err,
+			)
+		}
+	}
+
+	for _, v := range [][2]int{{1404, 366}, {1403, 367}, {1403, 0}, {1403, -1}} {
+		if ti, err := DateFromYearDay(v[0], v[1], Iran()); err == nil {
+			t.Error(
+				"For", fmt.Sprintf("%d/%d", v[0], v[1]),
+				"expected", "error",
+				"got", ti.String(),
+			)
+		}
+	}
+}
+
+func TestConfigurationRace(t *testing.T) {
+	defer SetAmPmNames("قبل از ظهر", "بعد از ظهر", "ق.ظ", "ب.ظ")
+	defer SetValueMode(ValueGregorian)
+	defer SetNowFunc(nil)
+	defer ClearHolidays()
+
+	ti := Date(1403, Farvardin, 1, 15, 0, 0, 0, Iran())
+	done := make(chan bool)
+	for i := 0; i < 4; i++ {
+		go func() {
+			for j := 0; j < 100; j++ {
+				ti.Format("yyyy/MM/dd hh:mm A a")
+				Parse("hh:mm a", "03:00 ب.ظ")
+				Now(Iran())
+				ti.Value()
+				ti.IsHoliday()
+				CountBusinessDays(ti, ti.AddDate(0, 1, 0))
+			}
+			done <- true
+		}()
+	}
+
+	go func() {
+		for j := 0; j < 100; j++ {
+			SetAmPmNames("صبح", "عصر", "ص", "ع")
+			SetValueMode(ValueJalaliDate)
+			SetNowFunc(func() time.Time { return ti.Time() })
+			RegisterHoliday(Holiday{Name: "test", Month: 1, Day: 2})
+			ClearHolidays()
+		}
+		done <- true
+	}()
+
+	for i := 0; i < 5; i++ {
+		<-done
+	}
+}
+
+func TestMonthBounds(t *testing.T) {
+	vals := []struct {
+		date pdate
+		last int
+	}{
+		{pdate{1403, Esfand, 15}, 30},
+		{pdate{1404, Esfand, 15}, 29},
+		{pdate{1402, Esfand, 29}, 29},
+		{pdate{1403, Shahrivar, 31}, 31},
+		{pdate{1403, Mehr, 1}, 30},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.date.year, v.date.month, v.date.day, 13, 14, 15, 16, Iran())
+
+		begin := ti.BeginningOfMonth()
+		if begin.Year() != v.date.year || begin.Month() != v.date.month || begin.Day() != 1 ||
+			begin.Hour() != 0 || begin.Minute() != 0 || begin.Second() != 0 || begin.Nanosecond() != 0 {
+			t.Error(
+				"For", ti.String(),
+				"expected", "the first day at 00:00:00",
+				"got", begin.String(),
+			)
+		}
+
+		end := ti.EndOfMonth()
+		if end.Year() != v.date.year || end.Month() != v.date.month || end.Day() != v.last ||
+			end.Hour() != 23 || end.Minute() != 59 || end.Second() != 59 || end.Nanosecond() != 999999999 {
+			t.Error(
+				"For", ti.String(),
+				"expected", fmt.Sprintf("day %d at 23:59:59.999999999", v.last),
+				"got", end.String(),
+			)
+		}
+
+		if next := end.Add(time.Nanosecond); next.Month() == v.date.month || next.Day() != 1 || next.Hour() != 0 {
+			t.Error(
+				"For", ti.String(),
+				"expected", "the first day of the next month",
+				"got", next.String(),
+			)
+		}
+	}
+}
+
+func TestYearBounds(t *testing.T) {
+	vals := []struct {
+		year, last int
+	}{
+		{1399, 30},
+		{1402, 29},
+		{1403, 30},
+		{1404, 29},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.year, Mehr, 10, 13, 14, 15, 16, Iran())
+
+		begin := ti.BeginningOfYear()
+		if begin.Year() != v.year || begin.Month() != Farvardin || begin.Day() != 1 ||
+			begin.Hour() != 0 || begin.Minute() != 0 || begin.Second() != 0 || begin.Nanosecond() != 0 {
+			t.Error(
+				"For", ti.String(),
+				"expected", "Farvardin 1 at 00:00:00",
+				"got", begin.String(),
+			)
+		}
+
+		end := ti.EndOfYear()
+		if end.Year() != v.year || end.Month() != Esfand || end.Day() != v.last ||
+			end.Hour() != 23 || end.Minute() != 59 || end.Second() != 59 || end.Nanosecond() != 999999999 {
+			t.Error(
+				"For", ti.String(),
+				"expected", fmt.Sprintf("Esfand %d at 23:59:59.999999999", v.last),
+				"got", end.String(),
+			)
+		}
+
+		if next := end.Add(time.Nanosecond); next.Year() != v.year+1 || next.Month() != Farvardin || next.Day() != 1 || next.Hour() != 0 {
+			t.Error(
+				"For", ti.String(),
+				"expected", "Farvardin 1 of the next year",
+				"got", next.String(),
+			)
+		}
+	}
+}
+
+func TestSameWeek(t *testing.T) {
+	// 1403/12/25 is a Shanbeh and 1404/01/01 is a Jomeh.
+	shanbeh := Date(1403, Esfand, 25, 0, 0, 0, 0, Iran())
+	jomeh := Date(1404, Farvardin, 1, 23, 59, 0, 0, Iran())
+	next := Date(1404, Farvardin, 2, 0, 0, 0, 0, Iran())
+
+	if !shanbeh.SameWeek(jomeh) || !jomeh.SameWeek(shanbeh) {
+		t.Error(
+			"For", shanbeh.String(), "and", jomeh.String(),
+			"expected", true,
+			"got", false,
+		)
+	}
+	if shanbeh.SameWeek(next) || next.SameWeek(jomeh) {
+		t.Error(
+			"For", shanbeh.String(), "and", next.String(),
+			"expected", false,
+			"got", true,
+		)
+	}
+
+	// 1404/01/01 21:00 UTC is 1404/01/02 00:30 in Tehran.
+	if u := Date(1404, Farvardin, 1, 21, 0, 0, 0, time.UTC); shanbeh.SameWeek(u) || !next.SameWeek(u) {
+		t.Error(
+			"For", u.String(),
+			"expected", "the week of 1404/01/02",
+			"got", shanbeh.SameWeek(u),
+		)
+	}
+
+	if !jomeh.SameWeekFrom(next, Jomeh) || jomeh.SameWeekFrom(shanbeh, Jomeh) {
+		t.Error(
+			"For", jomeh.String(),
+			"expected", "the week from Jomeh",
+			"got", jomeh.SameWeekFrom(next, Jomeh),
+		)
+	}
+}
+
+func TestFormatLatin(t *testing.T) {
+	months := []string{"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar", "Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand"}
+	for i, name := range months {
+		ti := Date(1403, Month(i+1), 1, 0, 0, 0, 0, Iran())
+		if s := ti.Format("d MMMT yyyy (MMM)"); s != fmt.Sprintf("1 %s 1403 (%s)", name, ti.Month()) {
+			t.Error(
+				"For", ti.Month(),
+				"expected", name,
+				"got", s,
+			)
+		}
+		if p, err := Parse("d MMMT yyyy", ti.Format("d MMMT yyyy")); err != nil || p.Month() != ti.Month() {
+			t.Error(
+				"For", name,
+				"expected", ti.Month(),
+				"got", p.Month(), err,
+			)
+		}
+	}
+
+	weekdays := []string{"Shanbeh", "Yekshanbeh", "Doshanbeh", "Seshanbeh", "Charshanbeh", "Panjshanbeh", "Jomeh"}
+	ti := Date(1403, Mehr, 7, 0, 0, 0, 0, Iran()) // a Shanbeh
+	for i, name := range weekdays {
+		d := ti.AddDate(0, 0, i)
+		if s := d.Format("ET/E/e"); s != fmt.Sprintf("%s/%s/%s", name, d.Weekday(), d.Weekday().Short()) {
+			t.Error(
+				"For", d.Weekday(),
+				"expected", name,
+				"got", s,
+			)
+		}
+		if Weekday(i).Latin() != name {
+			t.Error(
+				"For", Weekday(i),
+				"expected", name,
+				"got", Weekday(i).Latin(),
+			)
+		}
+	}
+}
+
+func TestGregorianWeekday(t *testing.T) {
+	for _, ti := range []Time{
+		Date(1394, Mehr, 2, 12, 0, 0, 0, Iran()),
+		Date(1403, Farvardin, 1, 0, 0, 0, 0, Iran()),
+		Date(1403, Esfand, 30, 23, 59, 59, 0, Iran()),
+		Date(1404, Farvardin, 1, 0, 30, 0, 0, Afghanistan()),
+		Date(961, Mehr, 23, 0, 0, 0, 0, time.UTC),
+	} {
+		wd := ti.GregorianWeekday()
+		if wd != ti.Time().Weekday() || FromStdWeekday(wd) != ti.Weekday() {
+			t.Error(
+				"For", ti.String(),
+				"expected", ti.Time().Weekday(),
+				"got", wd,
+			)
+		}
+	}
+
+	if wd := Date(1403, Farvardin, 1, 0, 0, 0, 0, Iran()).GregorianWeekday(); wd != time.Wednesday {
+		t.Error(
+			"For", "1403/01/01",
+			"expected", time.Wednesday,
+			"got", wd,
+		)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	approx := func(a, b float64) bool {
+		return math.Abs(a-b) < 1e-9
+	}
+
+	vals := []struct {
+		ti               Time
+		year, month, day float64
+	}{
+		{Date(1403, Farvardin, 1, 0, 0, 0, 0, Iran()), 0, 0, 0},
+		{Date(1403, Farvardin, 1, 12, 0, 0, 0, Iran()), 0.5 / 366, 0.5 / 31, 0.5},
+		{Date(1403, Mehr, 4, 0, 0, 0, 0, Iran()), 189.0 / 366, 3.0 / 30, 0},
+		{Date(1404, Mehr, 4, 0, 0, 0, 0, Iran()), 189.0 / 365, 3.0 / 30, 0},
+		{Date(1403, Esfand, 30, 18, 0, 0, 0, Iran()), 365.75 / 366, 29.75 / 30, 0.75},
+		{Date(1404, Esfand, 29, 18, 0, 0, 0, Iran()), 364.75 / 365, 28.75 / 29, 0.75},
+	}
+
+	for _, v := range vals {
+		if p := v.ti.YearProgress(); !approx(p, v.year) {
+			t.Error(
+				"For", v.ti.String(),
+				"expected", v.year,
+				"got", p,
+			)
+		}
+		if p := v.ti.MonthProgress(); !approx(p, v.month) {
+			t.Error(
+				"For", v.ti.String(),
+				"expected", v.month,
+				"got", p,
+			)
+		}
+		if p := v.ti.DayProgress(); !approx(p, v.day) {
+			t.Error(
+				"For", v.ti.String(),
+				"expected", v.day,
+				"got", p,
+			)
+		}
+	}
+
+	if p := Date(1403, Esfand, 30, 23, 59, 59, 999999999, Iran()).YearProgress(); p > 1 || p < 0.99999 {
+		t.Error(
+			"For", "the end of 1403",
+			"expected", "≈1",
+			"got", p,
+		)
+	}
+}
+
+func TestYearMonthRange(t *testing.T) {
+	start, end := MonthRange(1403, Esfand, Iran())
+	if start.String() != "1403-12-01T00:00:00+03:30" || end.String() != "1403-12-30T23:59:59.999999999+03:30" {
+		t.Error(
+			"For", "1403 Esfand",
+			"expected", "1403-12-01 to 1403-12-30",
+			"got", start.String(), end.String(),
+		)
+	}
+
+	start, end = MonthRange(1404, Esfand, Iran())
+	if start.String() != "1404-12-01T00:00:00+03:30" || end.String() != "1404-12-29T23:59:59.999999999+03:30" {
+		t.Error(
+			"For", "1404 Esfand",
+			"expected", "1404-12-01 to 1404-12-29",
+			"got", start.String(), end.String(),
+		)
+	}
+
+	start, end = YearRange(1403, Afghanistan())
+	if start.String() != "1403-01-01T00:00:00+04:30" || end.String() != "1403-12-30T23:59:59.999999999+04:30" {
+		t.Error(
+			"For", "1403",
+			"expected", "1403-01-01 to 1403-12-30",
+			"got", start.String(), end.String(),
+		)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error(
+					"For", "MonthRange(1403, 13)",
+					"expected", "panic",
+					"got", nil,
+				)
+			}
+		}()
+		MonthRange(1403, 13, Iran())
+	}()
+}
+
+func TestDiffIn(t *testing.T) {
+	// 1403 is a leap year and 1402 and 1404 are not.
+	vals := []struct {
+		t, u                                    Time
+		years, months, weeks, days, hours, secs int
+	}{
+		{
+			Date(1403, Esfand, 25, 10, 0, 0, 0, Iran()), Date(1404, Esfand, 25, 10, 0, 0, 0, Iran()),
+			1, 12, 52, 366, 8784, 31622400,
+		},
+		{
+			Date(1403, Esfand, 25, 10, 0, 0, 0, Iran()), Date(1404, Esfand, 25, 9, 59, 59, 999999999, Iran()),
+			0, 11, 52, 365, 8783, 31622399,
+		},
+		{
+			Date(1403, Esfand, 25, 10, 0, 0, 0, Iran()), Date(1402, Esfand, 25, 10, 0, 0, 0, Iran()),
+			-1, -12, -52, -365, -8760, -31536000,
+		},
+		{
+			Date(1403, Esfand, 30, 10, 0, 0, 0, Iran()), Date(1404, Esfand, 29, 10, 0, 0, 0, Iran()),
+			1, 12, 52, 365, 8760, 31536000,
+		},
+		{
+			Date(1403, Esfand, 30, 10, 0, 0, 0, Iran()), Date(1404, Esfand, 28, 10, 0, 0, 0, Iran()),
+			0, 11, 52, 364, 8736, 31449600,
+		},
+		{
+			Date(1403, Esfand, 30, 10, 0, 0, 0, Iran()), Date(1403, Esfand, 30, 11, 59, 59, 0, Afghanistan()),
+			0, 0, 0, 0, 0, 3599,
+		},
+	}
+
+	for _, v := range vals {
+		for unit, expected := range map[Unit]int{
+			UnitYear:   v.years,
+			UnitMonth:  v.months,
+			UnitWeek:   v.weeks,
+			UnitDay:    v.days,
+			UnitHour:   v.hours,
+			UnitMinute: v.secs / 60,
+			UnitSecond: v.secs,
+		} {
+			if d := v.t.DiffIn(unit, v.u); d != expected {
+				t.Error(
+					"For", fmt.Sprintf("%s to %s in unit %d", v.t.String(), v.u.String(), unit),
+					"expected", expected,
+					"got", d,
+				)
+			}
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error(
+					"For", "DiffIn(Unit(7))",
+					"expected", "panic",
+					"got", nil,
+				)
+			}
+		}()
+		vals[0].t.DiffIn(Unit(7), vals[0].u)
+	}()
+}
+
+func TestIsSupportedYear(t *testing.T) {
+	years := map[int]bool{
+		1177: false,
+		1178: true,
+		1403: true,
+		1633: true,
+		1634: false,
+		0:    false,
+		-1:   false,
+	}
+
+	for year, expected := range years {
+		if s := IsSupportedYear(year); s != expected {
+			t.Error(
+				"For", year,
+				"expected", expected,
+				"got", s,
+			)
+		}
+	}
+
+	if g := Date(1178, Farvardin, 1, 0, 0, 0, 0, time.UTC).Time(); g.Format("2006-01-02") != "1799-03-21" {
+		t.Error(
+			"For", "1178/01/01",
+			"expected", "1799-03-21",
+			"got", g,
+		)
+	}
+	if g := Date(1633, Esfand, 29, 0, 0, 0, 0, time.UTC).Time(); g.Format("2006-01-02") != "2255-03-20" {
+		t.Error(
+			"For", "1633/12/29",
+			"expected", "2255-03-20",
+			"got", g,
+		)
+	}
+}
+
+func TestDiffSigned(t *testing.T) {
+	vals := []struct {
+		t, u                Time
+		years, months, days int
+	}{
+		{Date(1403, Farvardin, 10, 10, 0, 0, 0, Iran()), Date(1404, Khordad, 15, 12, 0, 0, 0, Iran()), 1, 2, 5},
+		{Date(1403, Farvardin, 10, 10, 0, 0, 0, Iran()), Date(1403, Ordibehesht, 10, 9, 0, 0, 0, Iran()), 0, 0, 30},
+		{Date(1403, Farvardin, 10, 10, 0, 0, 0, Iran()), Date(1403, Ordibehesht, 10, 10, 0, 0, 0, Iran()), 0, 1, 0},
+		{Date(1403, Esfand, 30, 10, 0, 0, 0, Iran()), Date(1404, Esfand, 29, 10, 0, 0, 0, Iran()), 1, 0, 0},
+		{Date(1402, Esfand, 29, 23, 0, 0, 0, Iran()), Date(1403, Farvardin, 1, 1, 0, 0, 0, Iran()), 0, 0, 0},
+		{Date(1394, Mehr, 2, 12, 0, 0, 0, Iran()), Date(1403, Mehr, 1, 12, 0, 0, 0, Iran()), 8, 11, 30},
+	}
+
+	for _, v := range vals {
+		sign, years, months, days := v.t.DiffSigned(v.u)
+		if sign != 1 || years != v.years || months != v.months || days != v.days {
+			t.Error(
+				"For", v.t.String(), "to", v.u.String(),
+				"expected", 1, v.years, v.months, v.days,
+				"got", sign, years, months, days,
+			)
+		}
+
+		sign, years, months, days = v.u.DiffSigned(v.t)
+		if sign != -1 || years != v.years || months != v.months || days != v.days {
+			t.Error(
+				"For", v.u.String(), "to", v.t.String(),
+				"expected", -1, v.years, v.months, v.days,
+				"got", sign, years, months, days,
+			)
+		}
+	}
+
+	ti := Date(1403, Mehr, 2, 12, 0, 0, 0, Iran())
+	if sign, years, months, days := ti.DiffSigned(New(ti.Time().In(Afghanistan()))); sign != 0 || years != 0 || months != 0 || days != 0 {
+		t.Error(
+			"For", ti.String(),
+			"expected", 0, 0, 0, 0,
+			"got", sign, years, months, days,
+		)
+	}
+}
+
+func TestLeapYears(t *testing.T) {
+	if years := fmt.Sprint(LeapYears(1395, 1410)); years != "[1395 1399 1403 1408]" {
+		t.Error(
+			"For", "LeapYears(1395, 1410)",
+			"expected", "[1395 1399 1403 1408]",
+			"got", years,
+		)
+	}
+	if years := LeapYears(1403, 1403); len(years) != 1 || years[0] != 1403 {
+		t.Error(
+			"For", "LeapYears(1403, 1403)",
+			"expected", "[1403]",
+			"got", years,
+		)
+	}
+	if years := LeapYears(1404, 1407); years != nil {
+		t.Error(
+			"For", "LeapYears(1404, 1407)",
+			"expected", nil,
+			"got", years,
+		)
+	}
+	if years := LeapYears(1410, 1395); years != nil {
+		t.Error(
+			"For", "LeapYears(1410, 1395)",
+			"expected", nil,
+			"got", years,
+		)
+	}
+}
+
+func TestGregorianDayRange(t *testing.T) {
+	// The daylight saving time of Iran started on 1394/01/02 00:00 and ended on 1394/06/30 24:00.
+	vals := []struct {
+		ti    Time
+		start string
+		width time.Duration
+	}{
+		{Date(1403, Esfand, 30, 12, 0, 0, 0, Iran()), "2025-03-19T20:30:00Z", 24 * time.Hour},
+		{Date(1403, Farvardin, 1, 0, 0, 0, 0, Iran()), "2024-03-19T20:30:00Z", 24 * time.Hour},
+		{Date(1403, Farvardin, 1, 23, 59, 59, 999999999, Afghanistan()), "2024-03-19T19:30:00Z", 24 * time.Hour},
+		{Date(1394, Farvardin, 2, 12, 0, 0, 0, Iran()), "2015-03-21T20:30:00Z", 23 * time.Hour},
+		{Date(1394, Shahrivar, 30, 12, 0, 0, 0, Iran()), "2015-09-20T19:30:00Z", 25 * time.Hour},
+	}
+
+	for _, v := range vals {
+		start, end := v.ti.GregorianDayRange()
+		if s := start.UTC().Format(time.RFC3339); s != v.start || end.Sub(start) != v.width {
+			t.Error(
+				"For", v.ti.String(),
+				"expected", v.start, v.width,
+				"got", s, end.Sub(start),
+			)
+		}
+		if start.Location() != v.ti.Location() || !v.ti.Time().Before(end) || v.ti.Time().Before(start) {
+			t.Error(
+				"For", v.ti.String(),
+				"expected", "a range containing it",
+				"got", start, end,
+			)
+		}
+	}
+}
+
+func TestMonthMatrix(t *testing.T) {
+	// 1403/12/01 is a Charshanbeh and Esfand 1403 has 30 days.
+	golden := map[bool][6]string{
+		true: {
+			"11/27 11/28 11/29 11/30 12/01 12/02 12/03",
+			"12/04 12/05 12/06 12/07 12/08 12/09 12/10",
+			"12/11 12/12 12/13 12/14 12/15 12/16 12/17",
+			"12/18 12/19 12/20 12/21 12/22 12/23 12/24",
+			"12/25 12/26 12/27 12/28 12/29 12/30 01/01",
+			"01/02 01/03 01/04 01/05 01/06 01/07 01/08",
+		},
+		false: {
+			"----- ----- ----- ----- 12/01 12/02 12/03",
+			"12/04 12/05 12/06 12/07 12/08 12/09 12/10",
+			"12/11 12/12 12/13 12/14 12/15 12/16 12/17",
+			"12/18 12/19 12/20 12/21 12/22 12/23 12/24",
+			"12/25 12/26 12/27 12/28 12/29 12/30 -----",
+			"----- ----- ----- ----- ----- ----- -----",
+		},
+	}
+
+	for fill, rows := range golden {
+		grid := MonthMatrix(1403, Esfand, Iran(), fill)
+		for w, row := range grid {
+			cells := make([]string, len(row))
+			for d, cell := range row {
+				cells[d] = "-----"
+				if cell.Year() != 0 {
+					cells[d] = cell.Format("MM/dd")
+					if cell.Weekday() != Weekday(d) || cell.Hour() != 0 || cell.Location().String() != "Asia/Tehran" {
+						t.Error(
+							"For", cell.String(),
+							"expected", Weekday(d).String(),
+							"got", cell.Weekday().String(),
+						)
+					}
+				}
+			}
+			if s := strings.Join(cells, " "); s != rows[w] {
+				t.Error(
+					"For", fmt.Sprintf("row %d (fill %t)", w, fill),
+					"expected", rows[w],
+					"got", s,
+				)
+			}
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error(
+					"For", "MonthMatrix(1403, 0)",
+					"expected", "panic",
+					"got", nil,
+				)
+			}
+		}()
+		MonthMatrix(1403, 0, Iran(), true)
+	}()
+}
+
+func TestWeekYear(t *testing.T) {
+	// 1402/01/01 is a Seshanbeh, 1403/01/01 is a Charshanbeh and 1404/01/01 is a Jomeh.
+	vals := []struct {
+		date       pdate
+		year, week int
+	}{
+		{pdate{1401, Esfand, 26}, 1401, 52},
+		{pdate{1401, Esfand, 27}, 1402, 1},
+		{pdate{1401, Esfand, 29}, 1402, 1},
+		{pdate{1402, Farvardin, 1}, 1402, 1},
+		{pdate{1402, Farvardin, 5}, 1402, 2},
+		{pdate{1402, Esfand, 26}, 1402, 53},
+		{pdate{1402, Esfand, 29}, 1402, 53},
+		{pdate{1403, Farvardin, 1}, 1402, 53},
+		{pdate{1403, Farvardin, 3}, 1402, 53},
+		{pdate{1403, Farvardin, 4}, 1403, 1},
+		{pdate{1403, Mehr, 2}, 1403, 27},
+		{pdate{1403, Esfand, 30}, 1403, 52},
+		{pdate{1404, Farvardin, 1}, 1403, 52},
+		{pdate{1404, Farvardin, 2}, 1404, 1},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.date.year, v.date.month, v.date.day, 12, 0, 0, 0, Iran())
+		if year, week := ti.WeekYear(); year != v.year || week != v.week {
+			t.Error(
+				"For", ti.String(),
+				"expected", v.year, v.week,
+				"got", year, week,
+			)
+		}
+	}
+}
+
+func TestYearWeekBoundaries(t *testing.T) {
+	// 1403/01/01 is a Charshanbeh and 1404/01/01 is a Jomeh.
+	vals := []struct {
+		date pdate
+		week int
+	}{
+		{pdate{1403, Farvardin, 1}, 1},
+		{pdate{1403, Farvardin, 3}, 1},
+		{pdate{1403, Farvardin, 4}, 2},
+		{pdate{1403, Farvardin, 10}, 2},
+		{pdate{1403, Farvardin, 11}, 3},
+		{pdate{1403, Esfand, 25}, 53},
+		{pdate{1403, Esfand, 30}, 53},
+		{pdate{1404, Farvardin, 1}, 1},
+		{pdate{1404, Farvardin, 2}, 2},
+		{pdate{1404, Esfand, 29}, 53},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.date.year, v.date.month, v.date.day, 12, 0, 0, 0, Iran())
+		if w := ti.YearWeek(); w != v.week {
+			t.Error(
+				"For", ti.String(),
+				"expected", v.week,
+				"got", w,
+			)
+		}
+	}
+}
+
+func TestPersianWeekNumber(t *testing.T) {
+	// Nowruz of 1402, 1403 and 1404 fell on Tuesday March 21, 2023, Wednesday March 20, 2024
+	// and Friday March 21, 2025. The week numbers follow Calendar.GetWeekOfYear of .NET
+	// with CalendarWeekRule.FirstDay and DayOfWeek.Saturday.
+	vals := []struct {
+		g    time.Time
+		wd   time.Weekday
+		date pdate
+		week int
+	}{
+		{time.Date(2023, time.March, 21, 12, 0, 0, 0, Iran()), time.Tuesday, pdate{1402, Farvardin, 1}, 1},
+		{time.Date(2023, time.March, 24, 12, 0, 0, 0, Iran()), time.Friday, pdate{1402, Farvardin, 4}, 1},
+		{time.Date(2023, time.March, 25, 12, 0, 0, 0, Iran()), time.Saturday, pdate{1402, Farvardin, 5}, 2},
+		{time.Date(2024, time.March, 15, 12, 0, 0, 0, Iran()), time.Friday, pdate{1402, Esfand, 25}, 52},
+		{time.Date(2024, time.March, 16, 12, 0, 0, 0, Iran()), time.Saturday, pdate{1402, Esfand, 26}, 53},
+		{time.Date(2024, time.March, 19, 12, 0, 0, 0, Iran()), time.Tuesday, pdate{1402, Esfand, 29}, 53},
+		{time.Date(2024, time.March, 20, 12, 0, 0, 0, Iran()), time.Wednesday, pdate{1403, Farvardin, 1}, 1},
+		{time.Date(2024, time.March, 22, 12, 0, 0, 0, Iran()), time.Friday, pdate{1403, Farvardin, 3}, 1},
+		{time.Date(2024, time.March, 23, 12, 0, 0, 0, Iran()), time.Saturday, pdate{1403, Farvardin, 4}, 2},
+		{time.Date(2025, time.March, 14, 12, 0, 0, 0, Iran()), time.Friday, pdate{1403, Esfand, 24}, 52},
+		{time.Date(2025, time.March, 15, 12, 0, 0, 0, Iran()), time.Saturday, pdate{1403, Esfand, 25}, 53},
+		{time.Date(2025, time.March, 20, 12, 0, 0, 0, Iran()), time.Thursday, pdate{1403, Esfand, 30}, 53},
+		{time.Date(2025, time.March, 21, 12, 0, 0, 0, Iran()), time.Friday, pdate{1404, Farvardin, 1}, 1},
+		{time.Date(2025, time.March, 22, 12, 0, 0, 0, Iran()), time.Saturday, pdate{1404, Farvardin, 2}, 2},
+	}
+
+	for _, v := range vals {
+		ti := New(v.g)
+		if y, m, d := ti.Date(); v.g.Weekday() != v.wd || y != v.date.year || m != v.date.month || d != v.date.day {
+			t.Error(
+				"For", v.g,
+				"expected", fmt.Sprintf("%s %d/%d/%d", v.wd, v.date.year, v.date.month, v.date.day),
+				"got", fmt.Sprintf("%s %d/%d/%d", v.g.Weekday(), y, m, d),
+			)
+		}
+
+		if w := ti.PersianWeekNumber(); w != v.week {
+			t.Error(
+				"For", v.g,
+				"expected", v.week,
+				"got", w,
+			)
+		}
+	}
+}
+
+func TestLastWeekdayOfMonth(t *testing.T) {
+	vals := []struct {
+		date pdate
+		wd   Weekday
+		day  int
+	}{
+		{pdate{1403, Farvardin, 1}, Jomeh, 31},
+		{pdate{1403, Mehr, 15}, Jomeh, 27},
+		{pdate{1403, Esfand, 1}, Jomeh, 24},
+		{pdate{1403, Esfand, 1}, Panjshanbeh, 30},
+		{pdate{1404, Esfand, 1}, Jomeh, 29},
+		{pdate{1404, Esfand, 1}, Panjshanbeh, 28},
+		{pdate{1404, Esfand, 29}, Shanbeh, 23},
+	}
+
+	for _, v := range vals {
+		ti := Date(v.date.year, v.date.month, v.date.day, 9, 45, 0, 0, Iran())
+		d := ti.LastWeekdayOfMonth(v.wd)
+		if d.Year() != v.date.year || d.Month() != v.date.month || d.Day() != v.day || d.Weekday() != v.wd || d.Hour() != 9 || d.Minute() != 45 {
+			t.Error(
+				"For", fmt.Sprintf("%s of %s", v.wd, ti.String()),
+				"expected", v.day,
+				"got", d.String(),
+			)
+		}
+	}
+}
+
+func TestFormatConcurrent(t *testing.T) {
+	ti := Date(1394, Mehr, 2, 12, 59, 59, 50260050, Iran())
+	layouts := map[string]string{
+		"روز d MMM سال yyyy": "روز 2 مهر سال 1394",
+		"[yyyy-MM-dd]":       "[1394-07-02]",
+		"HH:mm:ss.S":         "12:59:59.050",
+		"--:--":              "--:--",
+		"":                   "",
+	}
+
+	done := make(chan bool)
+	for i := 0; i < 8; i++ {
+		go func() {
+			for layout, expected := range layouts {
+				if s := ti.Format(layout); s != expected {
+					t.Error(
+						"For", layout,
+						"expected", expected,
+						"got", s,
+					)
+				}
+			}
+			done <- true
+		}()
+	}
+	for i := 0; i < 8; i++ {
+		<-done
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	times := sortTimes(1000)
+	sort.Slice(times, func(i, j int) bool { return times[i].SortKey() < times[j].SortKey() })
+	for i := 1; i < len(times); i++ {
+		if times[i].Time().Before(times[i-1].Time()) {
+			t.Error(
+				"For", i,
+				"expected", times[i-1].String(),
+				"before", times[i].String(),
+			)
+		}
+	}
+
+	a := Date(1403, Esfand, 30, 23, 59, 59, 999999999, Afghanistan())
+	b := Date(1403, Esfand, 30, 23, 59, 59, 999999999, Iran())
+	if a.SortKey() >= b.SortKey() {
+		t.Error(
+			"For", a.String(),
+			"expected", "a key less than", b.SortKey(),
+			"got", a.SortKey(),
+		)
+	}
+}
+
+// sortTimes returns n pseudo-random times in different locations.
+func sortTimes(n int) []Time {
+	r := rand.New(rand.NewSource(1))
+	locs := []*time.Location{Iran(), Afghanistan(), time.UTC}
+	times := make([]Time, n)
+	for i := range times {
+		times[i] = New(time.Unix(r.Int63n(4e9)-2e9, r.Int63n(1e9)).In(locs[i%len(locs)]))
+	}
+	return times
+}
+
+func BenchmarkSortBySortKey(b *testing.B) {
+	times := sortTimes(1e6)
+	type keyed struct {
+		key int64
+		t   Time
+	}
+	s := make([]keyed, len(times))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, ti := range times {
+			s[j] = keyed{ti.SortKey(), ti}
+		}
+		sort.Slice(s, func(i, j int) bool { return s[i].key < s[j].key })
+	}
+}
+
+func BenchmarkSortByTime(b *testing.B) {
+	times := sortTimes(1e6)
+	s := make([]Time, len(times))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(s, times)
+		sort.Slice(s, func(i, j int) bool { return s[i].Time().Before(s[j].Time()) })
+	}
+}
+
+func BenchmarkFormat(b *testing.B) {
+	ti := Date(1394, Mehr, 2, 12, 59, 59, 50260050, Iran())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ti.Format("yyyy/MM/dd E HH:mm:ss.SSS Z")
+	}
 }
@@ -0,0 +1,66 @@
+package ptime
+
+// maxScheduleDays is the number of days which NextSchedule searches for a matching day.
+// It covers the longest gap between two leap years of the 33-year rule.
+const maxScheduleDays = 8 * 366
+
+// A Schedule specifies a recurring time of day on the days of the Persian calendar
+// which match all of its non-empty fields, e.g.
+//
+//	Schedule{Weekdays: []Weekday{Shanbeh}, At: Clock{Hour: 9}}    every Shanbeh at 09:00
+//	Schedule{Days: []int{1}}                                      the first day of each month at 00:00
+//	Schedule{Months: []Month{Esfand}, Days: []int{-1}}            the last day of each year at 00:00
+type Schedule struct {
+	// Months is the list of months. If it is empty, every month matches.
+	Months []Month
+	// Days is the list of days of month. A negative day counts from the end of the
+	// month, e.g. -1 is the last day. If it is empty, every day matches.
+	Days []int
+	// Weekdays is the list of weekdays. If it is empty, every weekday matches.
+	Weekdays []Weekday
+	// At is the time of day.
+	At Clock
+}
+
+// NextSchedule returns the first time at or after t which matches spec, in the location of t.
+//
+// It returns the zero Time if no day in the next 8 years matches spec, e.g. for Esfand 31.
+func (t Time) NextSchedule(spec Schedule) Time {
+	for jdn := t.jdn(); jdn < t.jdn()+maxScheduleDays; jdn++ {
+		y, m, d := getDate(jdn)
+		if !spec.matches(y, Month(m), d, jdnWeekday(jdn)) {
+			continue
+		}
+
+		next := Date(y, Month(m), d, spec.At.Hour, spec.At.Minute, 0, 0, t.loc)
+		if !next.Time().Before(t.Time()) {
+			return next
+		}
+	}
+	return Time{}
+}
+
+// matches reports whether the day of the month of the year falling on the weekday wd matches s.
+func (s Schedule) matches(year int, month Month, day int, wd Weekday) bool {
+	if len(s.Months) > 0 {
+		found := false
+		for _, m := range s.Months {
+			found = found || m == month
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(s.Days) > 0 {
+		found, n := false, monthLength(year, month)
+		for _, d := range s.Days {
+			found = found || d == day || d == day-n-1
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return len(s.Weekdays) == 0 || containsWeekday(s.Weekdays, wd)
+}
@@ -0,0 +1,51 @@
+package ptime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+func TestNextSchedule(t *testing.T) {
+	// 1403/12/25 is a Shanbeh and 1403/12/30 is a Panjshanbeh.
+	weekly := Schedule{Weekdays: []Weekday{Shanbeh}, At: Clock{Hour: 9}}
+	monthly := Schedule{Days: []int{1}}
+	lastDay := Schedule{Months: []Month{Esfand}, Days: []int{-1}, At: Clock{Hour: 23, Minute: 30}}
+
+	vals := []struct {
+		from     Time
+		spec     Schedule
+		expected string
+	}{
+		{Date(1403, Esfand, 25, 8, 59, 0, 0, Iran()), weekly, "1403-12-25T09:00:00+03:30"},
+		{Date(1403, Esfand, 25, 9, 0, 0, 0, Iran()), weekly, "1403-12-25T09:00:00+03:30"},
+		{Date(1403, Esfand, 25, 9, 0, 0, 1, Iran()), weekly, "1404-01-02T09:00:00+03:30"},
+		{Date(1403, Esfand, 28, 12, 0, 0, 0, Iran()), weekly, "1404-01-02T09:00:00+03:30"},
+		{Date(1403, Esfand, 15, 12, 0, 0, 0, Iran()), monthly, "1404-01-01T00:00:00+03:30"},
+		{Date(1403, Mehr, 1, 0, 0, 0, 0, Iran()), monthly, "1403-07-01T00:00:00+03:30"},
+		{Date(1403, Shahrivar, 31, 12, 0, 0, 0, Afghanistan()), monthly, "1403-07-01T00:00:00+04:30"},
+		{Date(1403, Farvardin, 1, 0, 0, 0, 0, Iran()), lastDay, "1403-12-30T23:30:00+03:30"},
+		{Date(1404, Farvardin, 1, 0, 0, 0, 0, Iran()), lastDay, "1404-12-29T23:30:00+03:30"},
+		{Date(1403, Esfand, 1, 0, 0, 0, 0, Iran()), Schedule{Days: []int{1, 15}, Weekdays: []Weekday{Jomeh}}, "1404-01-01T00:00:00+03:30"},
+		{Date(1404, Farvardin, 1, 0, 0, 0, 0, Iran()), Schedule{Months: []Month{Esfand}, Days: []int{30}}, "1408-12-30T00:00:00+03:30"},
+	}
+
+	for _, v := range vals {
+		if next := v.from.NextSchedule(v.spec); next.String() != v.expected {
+			t.Error(
+				"For", v.from.String(),
+				"expected", v.expected,
+				"got", next.String(),
+			)
+		}
+	}
+
+	if next := Date(1403, Farvardin, 1, 0, 0, 0, 0, time.UTC).NextSchedule(Schedule{Months: []Month{Esfand}, Days: []int{31}}); next.Year() != 0 {
+		t.Error(
+			"For", "Esfand 31",
+			"expected", "the zero Time",
+			"got", next.String(),
+		)
+	}
+}
@@ -0,0 +1,46 @@
+package ptime
+
+import (
+	"math"
+	"time"
+)
+
+// SolarNoon returns the instant of the local solar noon, i.e. the transit of the sun,
+// on the day of t at the given latitude and longitude in degrees, in the location of t.
+// The longitude is positive to the east of Greenwich (e.g. 51.389 for Tehran).
+//
+// The solar noon depends only on the longitude; lat is accepted for symmetry with the
+// other solar times. The result is accurate to about a minute.
+func (t Time) SolarNoon(lat, lon float64) Time {
+	y, m, d := t.Time().Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	// The equation of time is evaluated at the noon of Greenwich and then
+	// at the estimated solar noon of the longitude.
+	minutes := 720.0
+	for i := 0; i < 2; i++ {
+		jd := float64(midnight.Unix())/86400 + 2440587.5 + minutes/1440
+		minutes = 720 - 4*lon - equationOfTime(jd)
+	}
+
+	noon := midnight.Add(time.Duration(minutes * float64(time.Minute)))
+	return New(noon.In(t.loc))
+}
+
+// equationOfTime returns the equation of time in minutes at the Julian day jd
+// using the algorithm of the NOAA solar calculator.
+func equationOfTime(jd float64) float64 {
+	rad := math.Pi / 180
+	c := (jd - 2451545) / 36525
+
+	l0 := math.Mod(280.46646+c*(36000.76983+c*0.0003032), 360) * rad
+	m := (357.52911 + c*(35999.05029-0.0001537*c)) * rad
+	e := 0.016708634 - c*(0.000042037+0.0000001267*c)
+	obliquity := 23 + (26+(21.448-c*(46.815+c*(0.00059-c*0.001813)))/60)/60
+	obliquity += 0.00256 * math.Cos((125.04-1934.136*c)*rad)
+	y := math.Pow(math.Tan(obliquity*rad/2), 2)
+
+	eot := y*math.Sin(2*l0) - 2*e*math.Sin(m) + 4*e*y*math.Sin(m)*math.Cos(2*l0) -
+		0.5*y*y*math.Sin(4*l0) - 1.25*e*e*math.Sin(2*m)
+	return 4 * eot / rad
+}
@@ -0,0 +1,41 @@
+package ptime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/yaa110/go-persian-calendar"
+)
+
+func TestSolarNoon(t *testing.T) {
+	// The published solar noons of Tehran (35.6892°N, 51.3890°E).
+	noons := []struct {
+		date   pdate
+		hour   int
+		minute int
+	}{
+		{pdate{1402, Bahman, 22}, 12, 18},
+		{pdate{1403, Tir, 1}, 12, 6},
+		{pdate{1403, Aban, 13}, 11, 48},
+	}
+
+	for _, v := range noons {
+		ti := Date(v.date.year, v.date.month, v.date.day, 20, 0, 0, 0, Iran())
+		noon := ti.SolarNoon(35.6892, 51.3890)
+		expected := Date(v.date.year, v.date.month, v.date.day, v.hour, v.minute, 0, 0, Iran())
+		if d := noon.Time().Sub(expected.Time()); d < -2*time.Minute || d > 2*time.Minute {
+			t.Error(
+				"For", ti.String(),
+				"expected", expected.String(),
+				"got", noon.String(),
+			)
+		}
+		if noon.Location() != ti.Location() {
+			t.Error(
+				"For", ti.String(),
+				"expected", ti.Location(),
+				"got", noon.Location(),
+			)
+		}
+	}
+}